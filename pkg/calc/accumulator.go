@@ -0,0 +1,169 @@
+package calc
+
+import (
+	"fmt"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/samber/lo"
+)
+
+var combinationTypesCount = int(cards.StraightFlush) + 1
+
+// HandOddsAccumulator tallies iterations on the fly, so simulation memory doesn't grow with iterations count
+type HandOddsAccumulator struct {
+	IterationsCount int
+
+	// Iterations won by a single player
+	Wins []int
+	// Iterations where every player tied
+	Ties int
+	// Iterations where player split the pot with at least one other player
+	PlayerTies []int
+	// Sum of pot shares each player got, 1/k for a k-way split
+	Shares []float64
+	// Sum of squared pot shares, used to estimate variance of equity
+	SquaredShares []float64
+
+	// How often each player finished with each cards.CombinationType, indexed as [player][type]
+	CombinationTypes [][]int
+	// Iterations won by a single player with each cards.CombinationType, indexed as [player][type]
+	CombinationWins [][]int
+	// Sum of pot shares each player got with each cards.CombinationType, indexed as [player][type]
+	CombinationShares [][]float64
+
+	// How often each player finished with each SplitOutcome in hi-lo games, indexed as [player][outcome]
+	SplitOutcomes [][]int
+	// Sum of pot shares each player got with each SplitOutcome, indexed as [player][outcome]
+	SplitShares [][]float64
+
+	// Per combo tallies for simulations over ranges, indexed as [player][combo]
+	ComboDealt [][]int
+	ComboShares [][]float64
+}
+
+func NewHandOddsAccumulator(playersCount int) HandOddsAccumulator {
+	return HandOddsAccumulator{
+		Wins: make([]int, playersCount),
+		PlayerTies: make([]int, playersCount),
+		Shares: make([]float64, playersCount),
+		SquaredShares: make([]float64, playersCount),
+		CombinationTypes: lo.Times(playersCount, func(_ int) []int { return make([]int, combinationTypesCount) }),
+		CombinationWins: lo.Times(playersCount, func(_ int) []int { return make([]int, combinationTypesCount) }),
+		CombinationShares: lo.Times(playersCount, func(_ int) []float64 { return make([]float64, combinationTypesCount) }),
+		SplitOutcomes: lo.Times(playersCount, func(_ int) []int { return make([]int, splitOutcomesCount) }),
+		SplitShares: lo.Times(playersCount, func(_ int) []float64 { return make([]float64, splitOutcomesCount) }),
+	}
+}
+
+func (a *HandOddsAccumulator) trackCombos(rangeSizes []int) {
+	a.ComboDealt = lo.Map(rangeSizes, func(size int, _ int) []int {
+		return make([]int, size)
+	})
+	a.ComboShares = lo.Map(rangeSizes, func(size int, _ int) []float64 {
+		return make([]float64, size)
+	})
+}
+
+func (a HandOddsAccumulator) tracksCombos() bool {
+	return a.ComboDealt != nil
+}
+
+func (a HandOddsAccumulator) PlayersCount() int {
+	return len(a.Wins)
+}
+
+func (a *HandOddsAccumulator) Add(iteration HandOddsIteration) error {
+	if len(iteration.Combinations) != a.PlayersCount() {
+		return fmt.Errorf("Cannot accumulate iteration with {%d} players, expected {%d}", len(iteration.Combinations), a.PlayersCount())
+	}
+
+	shares, err := iteration.PotShares()
+	if err != nil {
+		return err
+	}
+
+	winners := []int{}
+	for player, share := range shares {
+		if share > 0 {
+			winners = append(winners, player)
+		}
+	}
+
+	if len(winners) == 1 {
+		a.Wins[winners[0]]++
+		a.CombinationWins[winners[0]][iteration.combinationType(winners[0])]++
+	} else if len(winners) == a.PlayersCount() {
+		a.Ties++
+	}
+
+	for _, winner := range winners {
+		a.Shares[winner] += shares[winner]
+		a.SquaredShares[winner] += shares[winner] * shares[winner]
+		a.CombinationShares[winner][iteration.combinationType(winner)] += shares[winner]
+		if len(winners) > 1 {
+			a.PlayerTies[winner]++
+		}
+	}
+
+	if a.tracksCombos() && len(iteration.HandIndexes) == a.PlayersCount() {
+		for player, combo := range iteration.HandIndexes {
+			a.ComboDealt[player][combo]++
+		}
+		for _, winner := range winners {
+			a.ComboShares[winner][iteration.HandIndexes[winner]] += shares[winner]
+		}
+	}
+
+	if iteration.isHiLo() {
+		outcomes, err := iteration.SplitOutcomes()
+		if err != nil {
+			return err
+		}
+
+		for _, winner := range winners {
+			a.SplitOutcomes[winner][outcomes[winner]]++
+			a.SplitShares[winner][outcomes[winner]] += shares[winner]
+		}
+	}
+
+	for player := range iteration.Combinations {
+		a.CombinationTypes[player][iteration.combinationType(player)]++
+	}
+
+	a.IterationsCount++
+	return nil
+}
+
+// Merge adds tallies of other accumulator with the same number of players
+func (a *HandOddsAccumulator) Merge(other HandOddsAccumulator) error {
+	if other.PlayersCount() != a.PlayersCount() {
+		return fmt.Errorf("Cannot merge accumulator of {%d} players into accumulator of {%d} players", other.PlayersCount(), a.PlayersCount())
+	}
+
+	a.IterationsCount += other.IterationsCount
+	a.Ties += other.Ties
+	for player := 0; player < a.PlayersCount(); player++ {
+		a.Wins[player] += other.Wins[player]
+		a.PlayerTies[player] += other.PlayerTies[player]
+		a.Shares[player] += other.Shares[player]
+		a.SquaredShares[player] += other.SquaredShares[player]
+		for ctype := range a.CombinationTypes[player] {
+			a.CombinationTypes[player][ctype] += other.CombinationTypes[player][ctype]
+			a.CombinationWins[player][ctype] += other.CombinationWins[player][ctype]
+			a.CombinationShares[player][ctype] += other.CombinationShares[player][ctype]
+		}
+		for outcome := range a.SplitOutcomes[player] {
+			a.SplitOutcomes[player][outcome] += other.SplitOutcomes[player][outcome]
+			a.SplitShares[player][outcome] += other.SplitShares[player][outcome]
+		}
+
+		if a.tracksCombos() && other.tracksCombos() {
+			for combo := range a.ComboDealt[player] {
+				a.ComboDealt[player][combo] += other.ComboDealt[player][combo]
+				a.ComboShares[player][combo] += other.ComboShares[player][combo]
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandOddsAccumulator_Add(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("single winner", func(t *testing.T) {
+			accumulator := NewHandOddsAccumulator(2)
+			err := accumulator.Add(HandOddsIteration{
+				Combinations: []cards.Combination{
+					combinationOf("KsTs7h8hKd"),
+					combinationOf("AhKh7h8hKd"),
+				},
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, 1, accumulator.IterationsCount)
+			require.Equal(t, []int{0, 1}, accumulator.Wins)
+			require.Equal(t, 0, accumulator.Ties)
+			require.Equal(t, []float64{0, 1}, accumulator.Shares)
+			require.Equal(t, 1, accumulator.CombinationTypes[0][cards.Pair])
+			require.Equal(t, 1, accumulator.CombinationTypes[1][cards.Pair])
+			require.Equal(t, 1, accumulator.CombinationWins[1][cards.Pair])
+			require.Equal(t, 0, accumulator.CombinationWins[0][cards.Pair])
+			require.Equal(t, 1.0, accumulator.CombinationShares[1][cards.Pair])
+		})
+
+		t.Run("two of three players split", func(t *testing.T) {
+			accumulator := NewHandOddsAccumulator(3)
+			err := accumulator.Add(HandOddsIteration{
+				Combinations: []cards.Combination{
+					combinationOf("AcAsKdKh8c"),
+					combinationOf("AhAdKsKc8d"),
+					combinationOf("AcAsKdKh7d"),
+				},
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, []int{0, 0, 0}, accumulator.Wins)
+			require.Equal(t, 0, accumulator.Ties)
+			require.Equal(t, []int{1, 1, 0}, accumulator.PlayerTies)
+			require.Equal(t, []float64{0.5, 0.5, 0}, accumulator.Shares)
+			require.Equal(t, []float64{0.25, 0.25, 0}, accumulator.SquaredShares)
+			require.Equal(t, 1, accumulator.CombinationTypes[2][cards.TwoPair])
+			require.Equal(t, 0, accumulator.CombinationWins[0][cards.TwoPair])
+			require.Equal(t, 0.5, accumulator.CombinationShares[0][cards.TwoPair])
+		})
+
+		t.Run("everybody ties", func(t *testing.T) {
+			accumulator := NewHandOddsAccumulator(2)
+			err := accumulator.Add(HandOddsIteration{
+				Combinations: []cards.Combination{
+					combinationOf("AsKsQsJsTs"),
+					combinationOf("AsKsQsJsTs"),
+				},
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, 1, accumulator.Ties)
+			require.Equal(t, []int{1, 1}, accumulator.PlayerTies)
+			require.Equal(t, []float64{0.5, 0.5}, accumulator.Shares)
+			require.Equal(t, 1, accumulator.CombinationTypes[0][cards.StraightFlush])
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Run("players count mismatch", func(t *testing.T) {
+			accumulator := NewHandOddsAccumulator(3)
+			err := accumulator.Add(HandOddsIteration{
+				Combinations: []cards.Combination{combinationOf("AsKsQsJsTs")},
+			})
+			require.Error(t, err)
+			require.Equal(t, 0, accumulator.IterationsCount)
+		})
+	})
+}
+
+func TestHandOddsAccumulator_Merge(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		first := NewHandOddsAccumulator(2)
+		require.NoError(t, first.Add(HandOddsIteration{
+			Combinations: []cards.Combination{combinationOf("KsTs7h8hKd"), combinationOf("AhKh7h8hKd")},
+		}))
+
+		second := NewHandOddsAccumulator(2)
+		require.NoError(t, second.Add(HandOddsIteration{
+			Combinations: []cards.Combination{combinationOf("KsTs2s6s7s"), combinationOf("AhKh7s6sAd")},
+		}))
+		require.NoError(t, second.Add(HandOddsIteration{
+			Combinations: []cards.Combination{combinationOf("AsKsQsJsTs"), combinationOf("AsKsQsJsTs")},
+		}))
+
+		require.NoError(t, first.Merge(second))
+		require.Equal(t, 3, first.IterationsCount)
+		require.Equal(t, []int{1, 1}, first.Wins)
+		require.Equal(t, 1, first.Ties)
+		require.Equal(t, []int{1, 1}, first.PlayerTies)
+		require.Equal(t, []float64{1.5, 1.5}, first.Shares)
+		require.Equal(t, []float64{1.25, 1.25}, first.SquaredShares)
+		require.Equal(t, 1, first.CombinationTypes[0][cards.Flush])
+		require.Equal(t, 1, first.CombinationWins[0][cards.Flush])
+		require.Equal(t, 0.5, first.CombinationShares[0][cards.StraightFlush])
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		first := NewHandOddsAccumulator(2)
+		second := NewHandOddsAccumulator(3)
+		require.Error(t, first.Merge(second))
+	})
+}
@@ -0,0 +1,111 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankHand_Custom(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("up to allowed cards", func(t *testing.T) {
+			// wheel takes a single hole card, which is only possible if hole cards are an upper limit
+			upTo := game.NewCustomConfig(cards.NewFullDeck, 4, 5, 2, 5, 6)
+			rank, combination := rankHand(parseCards("AsKsQsJs"), parseCards("Td2d3c4h5d"), nil, upTo)
+			require.Equal(t, cards.Straight, rank.Type())
+			require.ElementsMatch(t, parseCards("As2d3c4h5d"), combination)
+
+			exactly := upTo
+			exactly.CommunityCardsAllowedToUseCount = 3
+			exactly.MustUseExactly = true
+			rank, _ = rankHand(parseCards("AsKsQsJs"), parseCards("Td2d3c4h5d"), nil, exactly)
+			require.Equal(t, cards.HighCard, rank.Type())
+		})
+
+		t.Run("limited board cards", func(t *testing.T) {
+			// at most three board cards, so the board straight doesn't play without a hole card
+			config := game.NewCustomConfig(cards.NewFullDeck, 2, 5, 2, 3, 10)
+			rank, combination := rankHand(parseCards("2c2d"), parseCards("9sTdJhQcKd"), nil, config)
+			require.Equal(t, cards.Pair, rank.Type())
+			require.Len(t, combination, 5)
+			require.Subset(t, combination, parseCards("2c2d"))
+		})
+
+		t.Run("combination strengths", func(t *testing.T) {
+			config := game.NewCustomConfig(cards.NewFullDeck, 2, 5, 2, 5, 10)
+			config.CombinationStrengths = cards.ShortDeckCombinationStrength
+
+			flush, _ := rankHand(parseCards("As9s"), parseCards("Ks7s2sKdKc"), nil, config)
+			fullHouse, _ := rankHand(parseCards("AdAc"), parseCards("Ks7s2sKdKc"), nil, config)
+			require.Equal(t, cards.FullHouse, fullHouse.Type())
+			require.Greater(t, flush, fullHouse)
+		})
+
+		t.Run("short deck straights follow the deck", func(t *testing.T) {
+			config := game.NewCustomConfig(cards.NewShortDeck, 2, 5, 2, 5, 6)
+			require.True(t, config.ShortDeck)
+
+			rank, _ := rankHand(parseCards("AsKd"), parseCards("6c7d8h9sQd"), nil, config)
+			require.Equal(t, cards.Straight, rank.Type())
+
+			require.False(t, game.NewCustomConfig(cards.NewFullDeck, 2, 5, 2, 5, 6).ShortDeck)
+		})
+	})
+}
+
+func TestHandOdds_Custom(t *testing.T) {
+	seed := int64(3)
+
+	t.Run("positive", func(t *testing.T) {
+		cases := []struct {
+			custom game.Config
+			builtIn game.Config
+			hands [][]cards.Card
+		}{
+			{game.NewCustomConfig(cards.NewFullDeck, 2, 5, 2, 5, 10), game.NewTexasConfig(), [][]cards.Card{parseCards("AsKs"), parseCards("QdQc")}},
+			{game.NewCustomConfig(cards.NewShortDeck, 2, 5, 2, 5, 10), game.NewShortDeckConfig(), [][]cards.Card{parseCards("AsKs"), parseCards("QdQc")}},
+			{game.NewCustomConfig(cards.NewFullDeck, 7, 0, 5, 0, 7), game.NewStudConfig(), [][]cards.Card{parseCards("AsAdKc7h"), parseCards("QsQd9c5h")}},
+			{game.NewCustomConfig(cards.NewFullDeck, 4, 5, 2, 3, 10), game.NewOmahaConfig(), [][]cards.Card{parseCards("AsAdKsKd"), parseCards("QhJhTc9c")}},
+		}
+
+		for _, c := range cases {
+			c.custom.MustUseExactly = c.builtIn.MustUseExactly
+			config := HandOddsConfig{
+				Hands: c.hands,
+				IterationsCount: 2000,
+				GameConfig: c.custom,
+				Seed: &seed,
+			}
+			custom, err := HandOdds(config)
+			require.NoError(t, err)
+
+			config.GameConfig = c.builtIn
+			builtIn, err := HandOdds(config)
+			require.NoError(t, err)
+			require.Equal(t, builtIn.Equities(), custom.Equities(), c.builtIn.Game)
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		exactly := game.NewCustomConfig(cards.NewFullDeck, 4, 5, 2, 2, 10)
+		exactly.MustUseExactly = true
+
+		incomplete := game.NewCustomConfig(cards.NewFullDeck, 2, 5, 2, 5, 10)
+		incomplete.CombinationStrengths = []cards.CombinationType{cards.HighCard, cards.Pair, cards.StraightFlush}
+
+		for _, config := range []game.Config{
+			exactly,
+			game.NewCustomConfig(cards.NewFullDeck, 2, 5, 1, 3, 10),
+			incomplete,
+		} {
+			_, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{{}, {}},
+				IterationsCount: 100,
+				GameConfig: config,
+			})
+			require.Error(t, err)
+		}
+	})
+}
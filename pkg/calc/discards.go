@@ -0,0 +1,284 @@
+package calc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/evaluator"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+// DiscardEquity is equity of the player after discarding given cards
+type DiscardEquity struct {
+	Discard []cards.Card
+	Kept []cards.Card
+	Equity float64
+	// Simulation of the hand with this discard chosen
+	Result *HandOddsResult
+}
+
+func DiscardEquities(config HandOddsConfig, player int) ([]DiscardEquity, error) {
+	return DiscardEquitiesContext(context.Background(), config, player)
+}
+
+// DiscardEquitiesContext simulates every discard option of the player with fully known hand, the best option goes first.
+// Discards of other players are played as configured
+func DiscardEquitiesContext(ctx context.Context, config HandOddsConfig, player int) ([]DiscardEquity, error) {
+	if config.IterationsCount <= 0 {
+		return nil, fmt.Errorf("Cannot simulate hands odds for non-positive or zero iterations, was given {%d}", config.IterationsCount)
+	}
+
+	err := validateDiscards(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if player < 0 || player >= len(config.Hands) {
+		return nil, fmt.Errorf("Player {%d} is out of range, number of players: {%d}", player, len(config.Hands))
+	}
+
+	if len(config.Hands[player]) != config.GameConfig.HoleCardsCount {
+		return nil, fmt.Errorf("Cannot pick discard of player {%d} with {%d} known cards, should be {%d}", player, len(config.Hands[player]), config.GameConfig.HoleCardsCount)
+	}
+
+	options, err := discardEquities(ctx, config, player)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, option := range options {
+		if option.Result.IterationsCount() == 0 {
+			return nil, fmt.Errorf("Calculation was interrupted before the first iteration: %w", ctx.Err())
+		}
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].Equity > options[j].Equity
+	})
+	return options, nil
+}
+
+func validateDiscards(config HandOddsConfig) error {
+	if config.GameConfig.DiscardsCount <= 0 {
+		return errors.New("Cannot simulate discards in a game without them")
+	}
+
+	if len(config.Ranges) > 0 {
+		return errors.New("Cannot simulate discards for ranges, pass known hands instead")
+	}
+
+	if len(config.Discards) > 0 && len(config.Discards) != len(config.Hands) {
+		return fmt.Errorf("Discards are given for {%d} players, expected {%d}", len(config.Discards), len(config.Hands))
+	}
+
+	for player, discard := range config.Discards {
+		if len(discard) == 0 {
+			continue
+		}
+
+		if len(discard) != config.GameConfig.DiscardsCount {
+			return fmt.Errorf("Player {%d} discards {%d} cards, should be {%d}", player, len(discard), config.GameConfig.DiscardsCount)
+		}
+
+		for _, card := range discard {
+			if !lo.Contains(config.Hands[player], card) {
+				return fmt.Errorf("Player {%d} cannot discard {%v}, which is not in their hand", player, card)
+			}
+		}
+
+		if len(lo.FindDuplicates(discard)) > 0 {
+			return fmt.Errorf("Player {%d} discards the same card more than once", player)
+		}
+	}
+
+	err := validateIteration(config.Hands, config.Board, config.GameConfig)
+	if err != nil {
+		return err
+	}
+
+	return validateCards(config.Hands, config.Board, config.DeadCards, config.GameConfig)
+}
+
+// decidesDiscard reports whether player discards for the best equity: their discard is not chosen,
+// while their hand and the board at the time of discard are known
+func decidesDiscard(config HandOddsConfig, player int) bool {
+	chosen := len(config.Discards) > 0 && len(config.Discards[player]) > 0
+	return !chosen && len(config.Hands[player]) == config.GameConfig.HoleCardsCount && len(config.Board) >= config.GameConfig.DiscardBoardSize
+}
+
+func withDiscard(config HandOddsConfig, player int, discard []cards.Card) HandOddsConfig {
+	discards := make([][]cards.Card, len(config.Hands))
+	copy(discards, config.Discards)
+	discards[player] = discard
+
+	config.Discards = discards
+	return config
+}
+
+// discardOdds simulates games with discards. Players who decide their discard pick it one by one,
+// each keeping the option with the best equity
+func discardOdds(ctx context.Context, config HandOddsConfig) (*HandOddsResult, error) {
+	err := validateDiscards(config)
+	if err != nil {
+		return nil, err
+	}
+
+	decided := false
+	for player := range config.Hands {
+		if !decidesDiscard(config, player) {
+			continue
+		}
+
+		options, err := discardEquities(ctx, config, player)
+		if err != nil {
+			return nil, err
+		}
+
+		best := lo.MaxBy(options, func(a DiscardEquity, b DiscardEquity) bool {
+			return a.Equity > b.Equity
+		})
+		config = best.Result.Config
+		decided = true
+	}
+
+	if !decided {
+		return sampleDiscards(ctx, config)
+	}
+
+	// Equity of the best option is biased upwards by the very sample it was chosen on, so chosen discards are simulated anew
+	result, err := sampleDiscards(ctx, withIndependentSeed(config))
+	if err != nil {
+		return nil, err
+	}
+	result.Config.Seed = config.Seed
+	return result, nil
+}
+
+// withIndependentSeed derives a seed, whose blocks don't repeat the ones of config.Seed. Unseeded config is random anyway
+func withIndependentSeed(config HandOddsConfig) HandOddsConfig {
+	if config.Seed == nil {
+		return config
+	}
+
+	seed := rand.New(rand.NewSource(^*config.Seed)).Int63()
+	config.Seed = &seed
+	return config
+}
+
+func discardEquities(ctx context.Context, config HandOddsConfig, player int) ([]DiscardEquity, error) {
+	hand := config.Hands[player]
+
+	options := []DiscardEquity{}
+	for _, subset := range combin.Combinations(len(hand), config.GameConfig.DiscardsCount) {
+		discard := lo.Map(subset, func(index int, _ int) cards.Card {
+			return hand[index]
+		})
+
+		result, err := sampleDiscards(ctx, withDiscard(config, player, discard))
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, DiscardEquity{
+			Discard: discard,
+			Kept: lo.Without(hand, discard...),
+			Equity: result.Equities()[player],
+			Result: result,
+		})
+	}
+	return options, nil
+}
+
+func sampleDiscards(ctx context.Context, config HandOddsConfig) (*HandOddsResult, error) {
+	return sample(ctx, config, func(random *rand.Rand, _ int) (*HandOddsIteration, error) {
+		return iterateDiscards(config, random), nil
+	})
+}
+
+func iterateDiscards(config HandOddsConfig, random *rand.Rand) *HandOddsIteration {
+	gameConfig := config.GameConfig
+	deck := gameConfig.NewDeck()
+	deck.ShuffleWith(random)
+	deck = excludeCards(deck, collectExcludedCards(config.Board, config.Hands, config.DeadCards))
+	deck, hands := dealUnknownCards(deck, config.Hands, gameConfig.HoleCardsCount)
+
+	deck, drawnBeforeDiscard := drawCommunityCards(deck, config.Board, gameConfig.DiscardBoardSize)
+	board := append(append([]cards.Card{}, config.Board...), drawnBeforeDiscard...)
+
+	kept := lo.Map(hands, func(hand []cards.Card, player int) []cards.Card {
+		if len(config.Discards) > 0 && len(config.Discards[player]) > 0 {
+			return lo.Without(hand, config.Discards[player]...)
+		}
+		return keptAtDiscard(hand, board[:gameConfig.DiscardBoardSize], gameConfig)
+	})
+
+	deck, drawnAfterDiscard := drawCommunityCards(deck, board, gameConfig.CommunityCardsCount)
+
+	iteration := newHandOddsIteration(kept, config.Board, append(drawnBeforeDiscard, drawnAfterDiscard...), gameConfig)
+	return &iteration
+}
+
+// keptAtDiscard picks cards player keeps when their discard is not decided: the ones making the strongest hand
+// with the board dealt by then, or the best starting hand if there is no such board yet
+func keptAtDiscard(hand []cards.Card, board []cards.Card, gameConfig game.Config) []cards.Card {
+	keptCount := len(hand) - gameConfig.DiscardsCount
+
+	var subset []int
+	if keptCount + len(board) >= cards.CombinationLength {
+		var err error
+		_, subset, _, err = gameEvaluator(gameConfig).StrongestOf(evaluator.NewCards(hand), keptCount, evaluator.NewCards(board), cards.CombinationLength - keptCount)
+		if err != nil {
+			//This should never happen
+			panic(err)
+		}
+	} else {
+		subset = lo.MaxBy(combin.Combinations(len(hand), keptCount), func(a []int, b []int) bool {
+			return startingHandValue(hand, a) > startingHandValue(hand, b)
+		})
+	}
+
+	return lo.Map(subset, func(index int, _ int) cards.Card {
+		return hand[index]
+	})
+}
+
+// startingHandValue orders starting hands by pairs first, then by their faces from the highest, suited hands being better
+func startingHandValue(hand []cards.Card, subset []int) int {
+	kept := lo.Map(subset, func(index int, _ int) cards.Card {
+		return hand[index]
+	})
+
+	faces := lo.Map(kept, func(card cards.Card, _ int) int {
+		return int(card.Face())
+	})
+	sort.Sort(sort.Reverse(sort.IntSlice(faces)))
+
+	value := 0
+	if len(lo.Uniq(faces)) < len(faces) {
+		value = 1
+	}
+	for _, face := range faces {
+		value = value << 4 | face
+	}
+
+	value <<= 1
+	if len(lo.UniqBy(kept, cards.Card.Suit)) == 1 {
+		value |= 1
+	}
+	return value
+}
+
+// PlayerDiscard returns cards player discarded, whether chosen or picked for the best equity.
+// It is empty for players discarding by the simple strategy
+func (r HandOddsResult) PlayerDiscard(index int) []cards.Card {
+	if index < 0 || index >= len(r.Config.Discards) {
+		return nil
+	}
+	return r.Config.Discards[index]
+}
@@ -0,0 +1,152 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandOdds_Discards(t *testing.T) {
+	seed := int64(11)
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("chosen discards play as Texas Hold'em", func(t *testing.T) {
+			pineapple, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd2c"), parseCards("KsKd3h")},
+				Discards: [][]cards.Card{parseCards("2c"), parseCards("3h")},
+				IterationsCount: 2000,
+				GameConfig: game.NewPineappleConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+
+			texas, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				DeadCards: parseCards("2c3h"),
+				IterationsCount: 2000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Equal(t, texas.Equities(), pineapple.Equities())
+		})
+
+		t.Run("undecided discard is optimised", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd2c"), {}},
+				IterationsCount: 2000,
+				GameConfig: game.NewPineappleConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Equal(t, parseCards("2c"), result.Config.Discards[0])
+			require.Empty(t, result.Config.Discards[1])
+			require.Equal(t, &seed, result.Config.Seed)
+			require.Equal(t, 2000, result.IterationsCount())
+
+			// chosen discard is simulated on a sample independent of the one it was chosen on
+			options, err := DiscardEquities(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd2c"), {}},
+				IterationsCount: 2000,
+				GameConfig: game.NewPineappleConfig(),
+				Seed: &seed,
+			}, 0)
+			require.NoError(t, err)
+			require.Equal(t, parseCards("2c"), options[0].Discard)
+			require.NotEqual(t, options[0].Equity, result.Equities()[0])
+		})
+
+		t.Run("crazy pineapple discards after the flop", func(t *testing.T) {
+			options, err := DiscardEquities(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("2s2d9h"), parseCards("AhKh7c")},
+				Board: parseCards("9s9dTc"),
+				IterationsCount: 2000,
+				GameConfig: game.NewCrazyPineappleConfig(),
+				Seed: &seed,
+			}, 0)
+			require.NoError(t, err)
+			require.Len(t, options, 3)
+			// keeping 9h makes trips, while a pair of deuces only makes two pair
+			require.Contains(t, parseCards("2s2d"), options[0].Discard[0])
+			require.Contains(t, options[0].Kept, parseCards("9h")[0])
+			require.Equal(t, parseCards("9h"), options[2].Discard)
+			require.GreaterOrEqual(t, options[0].Equity, options[1].Equity)
+			require.GreaterOrEqual(t, options[1].Equity, options[2].Equity)
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		configs := []HandOddsConfig{
+			{
+				Hands: [][]cards.Card{parseCards("AsAd2c"), parseCards("KsKd3h")},
+				Discards: [][]cards.Card{parseCards("3h"), {}},
+				IterationsCount: 100,
+				GameConfig: game.NewPineappleConfig(),
+			},
+			{
+				Hands: [][]cards.Card{parseCards("AsAd2c"), parseCards("KsKd3h")},
+				Discards: [][]cards.Card{parseCards("2c")},
+				IterationsCount: 100,
+				GameConfig: game.NewPineappleConfig(),
+			},
+			{
+				Hands: [][]cards.Card{parseCards("AsAd2c"), parseCards("KsKd3h")},
+				Discards: [][]cards.Card{parseCards("2cAs"), {}},
+				IterationsCount: 100,
+				GameConfig: game.NewPineappleConfig(),
+			},
+			{
+				Hands: [][]cards.Card{parseCards("AsAd2c"), parseCards("KsKd")},
+				Discards: [][]cards.Card{parseCards("2c"), {}},
+				IterationsCount: 100,
+				GameConfig: game.NewTexasConfig(),
+			},
+		}
+
+		for _, config := range configs {
+			_, err := HandOdds(config)
+			require.Error(t, err)
+		}
+
+		_, err := DiscardEquities(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("AsAd"), {}},
+			IterationsCount: 100,
+			GameConfig: game.NewPineappleConfig(),
+		}, 0)
+		require.Error(t, err)
+
+		_, err = Outs(OutsConfig{
+			Hand: parseCards("AsAd2c"),
+			Opponents: []cards.Range{rangeOf(1, "KsKd3h")},
+			Board: parseCards("9s9dTc"),
+			GameConfig: game.NewCrazyPineappleConfig(),
+		})
+		require.Error(t, err)
+	})
+}
+
+func Test_keptAtDiscard(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		cases := []struct {
+			hand string
+			board string
+			kept string
+		}{
+			{"AsKd2c", "", "AsKd"},
+			{"7s7dAc", "", "7s7d"},
+			{"AsKdKs", "", "KdKs"},
+			{"AsKd2s", "", "AsKd"},
+			{"AsKdQd", "", "AsKd"},
+			{"AsQdKs", "", "AsKs"},
+			{"AsKd2c", "2h2s9c", "As2c"},
+			{"AsKd2c", "QsJsTs", "AsKd"},
+		}
+
+		for _, c := range cases {
+			kept := keptAtDiscard(parseCards(c.hand), parseCards(c.board), game.NewCrazyPineappleConfig())
+			require.ElementsMatch(t, parseCards(c.kept), kept, c.hand)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package calc
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+)
+
+// Lowball hands stand pat and drawn cards are kept when they are this low or lower
+const lowballDrawFace = cards.Eight
+
+func validateDraws(config HandOddsConfig) error {
+	if config.GameConfig.DrawsCount == 0 {
+		return fmt.Errorf("Cannot simulate draws in a game without them")
+	}
+
+	if len(config.Draws) != len(config.Hands) {
+		return fmt.Errorf("Draws are given for {%d} players, expected {%d}", len(config.Draws), len(config.Hands))
+	}
+
+	for player, draws := range config.Draws {
+		if draws < 0 || draws > config.GameConfig.DrawsCount {
+			return fmt.Errorf("Player {%d} cannot take {%d} draws, should be from {0} to {%d}", player, draws, config.GameConfig.DrawsCount)
+		}
+
+		if draws == 0 && len(config.Hands[player]) != config.GameConfig.HoleCardsCount {
+			return fmt.Errorf("Player {%d} keeps {%d} cards without draws left, should be {%d}", player, len(config.Hands[player]), config.GameConfig.HoleCardsCount)
+		}
+	}
+
+	err := validateIteration(config.Hands, config.Board, config.GameConfig)
+	if err != nil {
+		return err
+	}
+
+	return validateCards(config.Hands, config.Board, config.DeadCards, config.GameConfig)
+}
+
+// drawGameOdds simulates draws of every player, where Hands are the cards players keep on the next draw
+func drawGameOdds(ctx context.Context, config HandOddsConfig) (*HandOddsResult, error) {
+	err := validateDraws(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return sample(ctx, config, func(random *rand.Rand, _ int) (*HandOddsIteration, error) {
+		return iterateDraws(config, random), nil
+	})
+}
+
+func iterateDraws(config HandOddsConfig, random *rand.Rand) *HandOddsIteration {
+	gameConfig := config.GameConfig
+	deck := gameConfig.NewDeck()
+	deck.ShuffleWith(random)
+	deck = excludeCards(deck, collectExcludedCards(nil, config.Hands, config.DeadCards))
+
+	hands := lo.Map(config.Hands, func(hand []cards.Card, _ int) []cards.Card {
+		return append(make([]cards.Card, 0, gameConfig.HoleCardsCount), hand...)
+	})
+
+	// cards thrown during simulation, which are shuffled back into the deck once it runs out
+	discards := []cards.Card{}
+	for draw := 0; draw < lo.Max(config.Draws); draw++ {
+		for player := range hands {
+			if draw >= config.Draws[player] {
+				continue
+			}
+
+			if draw > 0 {
+				var thrown []cards.Card
+				hands[player], thrown = keepDrawnCards(hands[player], len(config.Hands[player]), gameConfig)
+				discards = append(discards, thrown...)
+			}
+
+			for len(hands[player]) < gameConfig.HoleCardsCount {
+				if deck.IsEmpty() {
+					deck = cards.NewDeckWithoutValidation(discards)
+					deck.ShuffleWith(random)
+					discards = []cards.Card{}
+				}
+
+				card, err := deck.Draw()
+				if err != nil {
+					//This should never happen
+					panic(err)
+				}
+				hands[player] = append(hands[player], *card)
+			}
+		}
+	}
+
+	iteration := newHandOddsIteration(hands, nil, nil, gameConfig)
+	return &iteration
+}
+
+// keepDrawnCards decides which cards player keeps on the next draw. Made hands stand pat, otherwise cards kept from the start
+// are never thrown, while drawn cards are kept only if they improve the hand
+func keepDrawnCards(hand []cards.Card, keptCount int, gameConfig game.Config) ([]cards.Card, []cards.Card) {
+	if isMadeDrawHand(hand, gameConfig) {
+		return hand, nil
+	}
+
+	kept := append(make([]cards.Card, 0, len(hand)), hand[:keptCount]...)
+	drawn := append([]cards.Card{}, hand[keptCount:]...)
+	sort.Sort(cards.ByFace(drawn))
+
+	thrown := []cards.Card{}
+	for _, card := range drawn {
+		if keepsDrawnCard(kept, hand, card, gameConfig) {
+			kept = append(kept, card)
+		} else {
+			thrown = append(thrown, card)
+		}
+	}
+
+	// keeping every card of a hand, which is not made, would stand pat on it
+	if len(kept) == len(hand) && len(kept) > keptCount {
+		thrown = append(thrown, kept[len(kept) - 1])
+		kept = kept[:len(kept) - 1]
+	}
+	return kept, thrown
+}
+
+// isMadeDrawHand reports whether hand is good enough to stand pat: eight-low or better in lowball, straight or better otherwise
+func isMadeDrawHand(hand []cards.Card, gameConfig game.Config) bool {
+	rank, _ := rankHand(hand, nil, nil, gameConfig)
+	if gameConfig.Ranking == cards.DeuceToSevenRanking {
+		highest := lo.MaxBy(hand, func(a cards.Card, b cards.Card) bool {
+			return a.Face() > b.Face()
+		})
+		return rank.Type() == cards.HighCard && highest.Face() <= lowballDrawFace
+	}
+
+	strengths := gameStrengths(gameConfig)
+	return lo.IndexOf(strengths, rank.Type()) >= lo.IndexOf(strengths, cards.Straight)
+}
+
+// keepsDrawnCard reports whether drawn card improves the hand: an unpaired low card in lowball, a card making a pair or better otherwise
+func keepsDrawnCard(kept []cards.Card, hand []cards.Card, card cards.Card, gameConfig game.Config) bool {
+	if gameConfig.Ranking == cards.DeuceToSevenRanking {
+		return card.Face() <= lowballDrawFace && !lo.SomeBy(kept, func(k cards.Card) bool {
+			return k.Face() == card.Face()
+		})
+	}
+
+	return lo.CountBy(hand, func(h cards.Card) bool {
+		return h.Face() == card.Face()
+	}) > 1
+}
+
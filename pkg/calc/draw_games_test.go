@@ -0,0 +1,122 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_keepDrawnCards(t *testing.T) {
+	t.Run("lowball", func(t *testing.T) {
+		kept, thrown := keepDrawnCards(parseCards("7s5d3c9h3d"), 2, game.NewTripleDrawConfig())
+		require.Equal(t, parseCards("7s5d3c"), kept)
+		require.ElementsMatch(t, parseCards("9h3d"), thrown)
+
+		kept, thrown = keepDrawnCards(parseCards("7s5d4c3h2d"), 2, game.NewTripleDrawConfig())
+		require.Equal(t, parseCards("7s5d4c3h2d"), kept)
+		require.Empty(t, thrown)
+
+		kept, thrown = keepDrawnCards(parseCards("7h5h4h3h2h"), 4, game.NewTripleDrawConfig())
+		require.Equal(t, parseCards("7h5h4h3h"), kept)
+		require.Equal(t, parseCards("2h"), thrown)
+	})
+
+	t.Run("high", func(t *testing.T) {
+		kept, thrown := keepDrawnCards(parseCards("AsAdAc7h2d"), 2, game.NewFiveCardDrawConfig())
+		require.Equal(t, parseCards("AsAdAc"), kept)
+		require.ElementsMatch(t, parseCards("7h2d"), thrown)
+
+		kept, _ = keepDrawnCards(parseCards("AsAd7h2c7d"), 2, game.NewFiveCardDrawConfig())
+		require.ElementsMatch(t, parseCards("AsAd7h7d"), kept)
+
+		kept, thrown = keepDrawnCards(parseCards("AsKsQsJs9s"), 4, game.NewFiveCardDrawConfig())
+		require.Equal(t, parseCards("AsKsQsJs9s"), kept)
+		require.Empty(t, thrown)
+	})
+}
+
+func TestHandOdds_DrawGames(t *testing.T) {
+	seed := int64(9)
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("pat nuts cannot lose", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("7s5d4c3h2s"), parseCards("8s6d")},
+				Draws: []int{0, 3},
+				IterationsCount: 4000,
+				GameConfig: game.NewTripleDrawConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Zero(t, result.Accumulator.Wins[1])
+			require.Greater(t, result.Equities()[0], 0.95)
+		})
+
+		t.Run("more draws are better", func(t *testing.T) {
+			three, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("7s5d3c2h"), parseCards("9s6h4h3s2c")},
+				Draws: []int{3, 0},
+				IterationsCount: 4000,
+				GameConfig: game.NewTripleDrawConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+
+			one, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("7s5d3c2h"), parseCards("9s6h4h3s2c")},
+				Draws: []int{1, 0},
+				IterationsCount: 4000,
+				GameConfig: game.NewTripleDrawConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Greater(t, three.Equities()[0], one.Equities()[0])
+		})
+
+		t.Run("five card draw", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				Draws: []int{1, 1},
+				IterationsCount: 4000,
+				GameConfig: game.NewFiveCardDrawConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Greater(t, result.Equities()[0], 0.6)
+		})
+
+		t.Run("discards are reshuffled once deck runs out", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{{}, {}, {}, {}, {}, {}},
+				Draws: []int{3, 3, 3, 3, 3, 3},
+				IterationsCount: 4000,
+				GameConfig: game.NewTripleDrawConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Equal(t, 4000, result.IterationsCount())
+			require.InDelta(t, 1.0, lo.Sum(result.Equities()), 1e-9)
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		cases := map[string]HandOddsConfig{
+			"game without draws": {Hands: [][]cards.Card{parseCards("AsAd"), {}}, Draws: []int{1, 1}, GameConfig: game.NewTexasConfig()},
+			"draws of every player": {Hands: [][]cards.Card{parseCards("AsAd"), {}}, Draws: []int{1}, GameConfig: game.NewFiveCardDrawConfig()},
+			"too many draws": {Hands: [][]cards.Card{parseCards("AsAd"), {}}, Draws: []int{2, 1}, GameConfig: game.NewFiveCardDrawConfig()},
+			"incomplete pat hand": {Hands: [][]cards.Card{parseCards("AsAd"), {}}, Draws: []int{0, 1}, GameConfig: game.NewFiveCardDrawConfig()},
+			"duplicate cards": {Hands: [][]cards.Card{parseCards("AsAd"), parseCards("As")}, Draws: []int{1, 1}, GameConfig: game.NewFiveCardDrawConfig()},
+		}
+
+		for name, config := range cases {
+			t.Run(name, func(t *testing.T) {
+				config.IterationsCount = 100
+				_, err := HandOdds(config)
+				require.Error(t, err)
+			})
+		}
+	})
+}
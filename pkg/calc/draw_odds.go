@@ -0,0 +1,131 @@
+package calc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/evaluator"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+)
+
+type DrawOddsConfig struct {
+	Hand []cards.Card
+	Board []cards.Card
+	DeadCards []cards.Card
+	// Runouts finishing with this combination type or a stronger one in the game order are counted as hits
+	CombinationType cards.CombinationType
+	GameConfig game.Config
+
+	// Number of workers runouts are split between, defaults to GOMAXPROCS
+	Threads int
+}
+
+type DrawOddsResult struct {
+	Config DrawOddsConfig
+	// Number of enumerated runouts, less than the number of all runouts if interrupted
+	RunoutsCount int
+	// true if enumeration was stopped by context, probabilities are then estimated from enumerated runouts
+	Interrupted bool
+	// Number of runouts finishing with every combination type, indexed by cards.CombinationType
+	CombinationTypes []int
+}
+
+// CombinationStrengths returns combination types of the game from the weakest to the strongest
+func (r DrawOddsResult) CombinationStrengths() []cards.CombinationType {
+	return gameStrengths(r.Config.GameConfig)
+}
+
+// Hits returns number of runouts finishing with the target combination type or better
+func (r DrawOddsResult) Hits() int {
+	strengths := r.CombinationStrengths()
+	target := lo.IndexOf(strengths, r.Config.CombinationType)
+
+	hits := 0
+	for _, combinationType := range strengths[target:] {
+		hits += r.CombinationTypes[combinationType]
+	}
+	return hits
+}
+
+// Probability of finishing with the target combination type or better
+func (r DrawOddsResult) Probability() float64 {
+	return float64(r.Hits()) / float64(r.RunoutsCount)
+}
+
+// TypeProbability returns probability of finishing with exactly given combination type
+func (r DrawOddsResult) TypeProbability(combinationType cards.CombinationType) float64 {
+	return float64(r.CombinationTypes[combinationType]) / float64(r.RunoutsCount)
+}
+
+func validateDrawOdds(config DrawOddsConfig) error {
+	if config.GameConfig.HiLo {
+		return errors.New("Cannot count draw odds in hi-lo games, only the high half of the pot would be counted")
+	}
+
+	if config.GameConfig.DiscardsCount > 0 {
+		return errors.New("Cannot count draw odds in games with discards, pass kept cards to Texas Hold'em instead")
+	}
+
+	if len(config.Hand) != config.GameConfig.HoleCardsCount {
+		return fmt.Errorf("Hand of invalid size {%d}, should be {%d}", len(config.Hand), config.GameConfig.HoleCardsCount)
+	}
+
+	if !lo.Contains(gameStrengths(config.GameConfig), config.CombinationType) {
+		return fmt.Errorf("Unknown combination type {%d}", config.CombinationType)
+	}
+
+	err := validateIteration([][]cards.Card{config.Hand}, config.Board, config.GameConfig)
+	if err != nil {
+		return err
+	}
+
+	return validateCards([][]cards.Card{config.Hand}, config.Board, config.DeadCards, config.GameConfig)
+}
+
+// DrawOdds enumerates every runout of the board and counts combinations the hand finishes with
+func DrawOdds(config DrawOddsConfig) (*DrawOddsResult, error) {
+	return DrawOddsContext(context.Background(), config)
+}
+
+// DrawOddsContext stops on cancellation or deadline of ctx and returns result of runouts enumerated so far
+func DrawOddsContext(ctx context.Context, config DrawOddsConfig) (*DrawOddsResult, error) {
+	err := validateDrawOdds(config)
+	if err != nil {
+		return nil, err
+	}
+
+	handOddsConfig := HandOddsConfig{
+		Hands: [][]cards.Card{config.Hand},
+		Board: config.Board,
+		DeadCards: config.DeadCards,
+		GameConfig: config.GameConfig,
+		Threads: config.Threads,
+	}
+
+	// Every runout is tallied as a hand of a single player. Only its rank is evaluated, which is enough to tell combination type
+	result, err := enumerateRunouts(ctx, handOddsConfig, func(extraCommunityCards []cards.Card) HandOddsIteration {
+		rank, _ := rankHand(config.Hand, config.Board, extraCommunityCards, config.GameConfig)
+		return HandOddsIteration{
+			Combinations: make([]cards.Combination, 1),
+			Ranks: []evaluator.Rank{rank},
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	enumerated := result.IterationsCount()
+	if enumerated == 0 {
+		return nil, fmt.Errorf("Calculation was interrupted before the first runout: %w", ctx.Err())
+	}
+
+	return &DrawOddsResult{
+		Config: config,
+		RunoutsCount: enumerated,
+		Interrupted: result.Interrupted,
+		CombinationTypes: result.Accumulator.CombinationTypes[0],
+	}, nil
+}
@@ -0,0 +1,166 @@
+package calc
+
+import (
+	"context"
+	"time"
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrawOdds(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("texas flush draw on the flop", func(t *testing.T) {
+			result, err := DrawOdds(DrawOddsConfig{
+				Hand: parseCards("AsKs"),
+				Board: parseCards("Qs7s2d"),
+				CombinationType: cards.Flush,
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1081, result.RunoutsCount)
+			require.Equal(t, 1081, lo.Sum(result.CombinationTypes))
+
+			// 9 spades out of 47 cards, full house is not possible
+			require.Equal(t, 1081 - 703, result.Hits())
+			require.InDelta(t, 378.0 / 1081, result.Probability(), 1e-9)
+		})
+
+		t.Run("omaha uses exactly two hole cards and three board cards", func(t *testing.T) {
+			// four spades in hand are useless without the third spade on board
+			result, err := DrawOdds(DrawOddsConfig{
+				Hand: parseCards("AsKsQsJs"),
+				Board: parseCards("2s3s7d"),
+				CombinationType: cards.Flush,
+				GameConfig: game.NewOmahaConfig(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 990, result.RunoutsCount)
+			require.Equal(t, 990 - 703, result.Hits())
+		})
+
+		t.Run("short-deck strength order", func(t *testing.T) {
+			config := DrawOddsConfig{
+				Hand: parseCards("AsKs"),
+				Board: parseCards("Qs7s6d"),
+				CombinationType: cards.FullHouse,
+				GameConfig: game.NewShortDeckConfig(),
+			}
+
+			// flush beats full house in short-deck, so 5 spades out of 31 cards are hits
+			result, err := DrawOdds(config)
+			require.NoError(t, err)
+			require.Equal(t, 465, result.RunoutsCount)
+			require.Equal(t, 465 - 325, result.Hits())
+
+			config.GameConfig = game.NewTexasConfig()
+			config.Board = parseCards("Qs7s2d")
+			result, err = DrawOdds(config)
+			require.NoError(t, err)
+			// only JsTs, making royal flush
+			require.Equal(t, 1, result.Hits())
+		})
+
+		t.Run("short-deck A6789 straight", func(t *testing.T) {
+			result, err := DrawOdds(DrawOddsConfig{
+				Hand: parseCards("As9h"),
+				Board: parseCards("6c7d8h"),
+				CombinationType: cards.Straight,
+				GameConfig: game.NewShortDeckConfig(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1.0, result.Probability())
+		})
+
+		t.Run("complete board", func(t *testing.T) {
+			result, err := DrawOdds(DrawOddsConfig{
+				Hand: parseCards("AsKs"),
+				Board: parseCards("Qs7s2d3s4c"),
+				CombinationType: cards.Pair,
+				DeadCards: parseCards("Js"),
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1, result.RunoutsCount)
+			require.Equal(t, 1.0, result.TypeProbability(cards.Flush))
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		invalid := map[string]DrawOddsConfig{
+			"hand of invalid size": {
+				Hand: parseCards("AsKsQs"),
+				CombinationType: cards.Flush,
+				GameConfig: game.NewTexasConfig(),
+			},
+			"card outside of deck": {
+				Hand: parseCards("As2s"),
+				CombinationType: cards.Flush,
+				GameConfig: game.NewShortDeckConfig(),
+			},
+			"dead card on board": {
+				Hand: parseCards("AsKs"),
+				Board: parseCards("Qs7s2d"),
+				DeadCards: parseCards("2d"),
+				CombinationType: cards.Flush,
+				GameConfig: game.NewTexasConfig(),
+			},
+			"game with discards": {
+				Hand: parseCards("AsKsQd"),
+				Board: parseCards("2s3s4d"),
+				CombinationType: cards.Flush,
+				GameConfig: game.NewCrazyPineappleConfig(),
+			},
+			"hi-lo game": {
+				Hand: parseCards("AsKs2d3c"),
+				CombinationType: cards.Flush,
+				GameConfig: game.NewOmahaHiLoConfig(),
+			},
+			"unknown combination type": {
+				Hand: parseCards("AsKs"),
+				CombinationType: cards.CombinationType(42),
+				GameConfig: game.NewTexasConfig(),
+			},
+		}
+
+		for name, config := range invalid {
+			t.Run(name, func(t *testing.T) {
+				result, err := DrawOdds(config)
+				require.Error(t, err)
+				require.Nil(t, result)
+			})
+		}
+	})
+}
+
+func TestDrawOddsContext(t *testing.T) {
+	config := DrawOddsConfig{
+		Hand: parseCards("AsKsQs9d"),
+		CombinationType: cards.Flush,
+		GameConfig: game.NewOmahaConfig(),
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50 * time.Millisecond)
+		defer cancel()
+
+		result, err := DrawOddsContext(ctx, config)
+		require.NoError(t, err)
+		require.True(t, result.Interrupted)
+		require.Greater(t, result.RunoutsCount, 0)
+		require.Equal(t, result.RunoutsCount, lo.Sum(result.CombinationTypes))
+		require.Greater(t, result.Probability(), 0.0)
+		require.Less(t, result.Probability(), 1.0)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := DrawOddsContext(ctx, config)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+package calc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+func remainingCards(hands [][]cards.Card, board []cards.Card, deadCards []cards.Card, gameConfig game.Config) []cards.Card {
+	deck := gameConfig.NewDeck()
+	excludedCards := collectExcludedCards(board, hands, deadCards)
+	deck = excludeCards(deck, excludedCards)
+	return deck.LeftCards()
+}
+
+// RunoutsCount returns the number of distinct boards that can complete config.Board
+func RunoutsCount(config HandOddsConfig) (int, error) {
+	config, err := joinUpCards(config)
+	if err != nil {
+		return 0, err
+	}
+
+	if config.HasUnknownCards() {
+		return 0, errors.New("Cannot count runouts for hands with unknown cards")
+	}
+
+	err = validateIteration(config.Hands, config.Board, config.GameConfig)
+	if err != nil {
+		return 0, err
+	}
+
+	err = validateCards(config.Hands, config.Board, config.DeadCards, config.GameConfig)
+	if err != nil {
+		return 0, err
+	}
+
+	left := remainingCards(config.Hands, config.Board, config.DeadCards, config.GameConfig)
+	cardsToDraw := config.GameConfig.CommunityCardsCount - len(config.Board)
+	return combin.Binomial(len(left), cardsToDraw), nil
+}
+
+// runoutStride returns step, which is coprime with runouts count and close to its golden section.
+// Visiting runouts with it scatters them evenly over the deck, so interrupted enumeration is close to a random sample
+func runoutStride(runouts int) int {
+	stride := max(int(float64(runouts) / math.Phi), 1)
+	for gcd(stride, runouts) != 1 {
+		stride++
+	}
+	return stride
+}
+
+// runoutIndex returns index of the runout enumerated by job. Product is taken in int64, since it overflows int on 32-bit platforms
+func runoutIndex(job int, stride int, runouts int) int {
+	return int(int64(job) * int64(stride) % int64(runouts))
+}
+
+func gcd(a int, b int) int {
+	for b != 0 {
+		a, b = b, a % b
+	}
+	return a
+}
+
+func enumerate(ctx context.Context, config HandOddsConfig) (*HandOddsResult, error) {
+	err := validateIteration(config.Hands, config.Board, config.GameConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateCards(config.Hands, config.Board, config.DeadCards, config.GameConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := enumerateRunouts(ctx, config, func(extraCommunityCards []cards.Card) HandOddsIteration {
+		return newHandOddsIteration(config.Hands, config.Board, extraCommunityCards, config.GameConfig)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Exhaustive = !result.Interrupted
+	return result, nil
+}
+
+// enumerateRunouts splits every runout completing config.Board between workers of runInParallel, iterate evaluates a single one
+func enumerateRunouts(ctx context.Context, config HandOddsConfig, iterate func(extraCommunityCards []cards.Card) HandOddsIteration) (*HandOddsResult, error) {
+	left := remainingCards(config.Hands, config.Board, config.DeadCards, config.GameConfig)
+	cardsToDraw := config.GameConfig.CommunityCardsCount - len(config.Board)
+
+	runouts := combin.Binomial(len(left), cardsToDraw)
+
+	stride := runoutStride(runouts)
+	return runInParallel(ctx, config, runouts, func(_ *rand.Rand, job int) (*HandOddsIteration, error) {
+		runout := runoutIndex(job, stride, runouts)
+		runoutIndexes := combin.IndexToCombination(nil, runout, len(left), cardsToDraw)
+		extraCommunityCards := lo.Map(runoutIndexes, func(cardIndex int, _ int) cards.Card {
+			return left[cardIndex]
+		})
+
+		iteration := iterate(extraCommunityCards)
+		return &iteration, nil
+	})
+}
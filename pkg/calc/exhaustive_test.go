@@ -0,0 +1,326 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	cmd "github.com/anuarkaliyev23/goker/pkg/cmd/utils"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCards(representation string) []cards.Card {
+	cs, err := cmd.ParseCards(representation)
+	if err != nil {
+		panic(err)
+	}
+	return cs
+}
+
+func TestRunoutsCount(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("river", func(t *testing.T) {
+			runouts, err := RunoutsCount(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				Board: parseCards("2c3c4h9sTs"),
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1, runouts)
+		})
+
+		t.Run("turn", func(t *testing.T) {
+			runouts, err := RunoutsCount(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				Board: parseCards("2c3c4h9s"),
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 44, runouts)
+		})
+
+		t.Run("flop", func(t *testing.T) {
+			runouts, err := RunoutsCount(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				Board: parseCards("2c3c4h"),
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 990, runouts)
+		})
+	})
+}
+
+func TestHandOdds_Exhaustive(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("KsTh vs 8d7d, board: KdTsTd2d", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("KsTh"), parseCards("8d7d")},
+				Board: parseCards("KdTsTd2d"),
+				GameConfig: game.NewTexasConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.True(t, odds.Exhaustive)
+			require.Equal(t, 44, odds.IterationsCount())
+
+			wins, err := odds.AllPlayerWins()
+			require.NoError(t, err)
+			require.Equal(t, []int{44, 0}, wins)
+		})
+
+		t.Run("AsAd vs KsKd, board: Kh7c2d5s", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				Board: parseCards("Kh7c2d5s"),
+				GameConfig: game.NewTexasConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, 44, odds.IterationsCount())
+
+			// Only the two remaining aces save AsAd
+			wins, err := odds.AllPlayerWins()
+			require.NoError(t, err)
+			require.Equal(t, []int{2, 42}, wins)
+		})
+
+		t.Run("board plays on the river", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("2s3d"), parseCards("4s2d")},
+				Board: parseCards("AhKhQhJhTh"),
+				GameConfig: game.NewTexasConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1, odds.IterationsCount())
+
+			ties, err := odds.TiePercentage()
+			require.NoError(t, err)
+			require.Equal(t, float32(1.0), ties)
+		})
+
+		t.Run("falls back to sampling above threshold", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				Board: parseCards("2c3c4h"),
+				IterationsCount: 100,
+				GameConfig: game.NewTexasConfig(),
+				Exhaustive: true,
+				ExhaustiveThreshold: 500,
+			})
+			require.NoError(t, err)
+			require.False(t, odds.Exhaustive)
+			require.Equal(t, 100, odds.IterationsCount())
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Run("too many players", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: generateHands(23),
+				GameConfig: game.NewTexasConfig(),
+				Exhaustive: true,
+			})
+			require.Error(t, err)
+			require.Nil(t, odds)
+		})
+	})
+}
+
+func TestHandOdds_ShortDeck(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("runouts are drawn from short deck", func(t *testing.T) {
+			config := HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("As9h"), parseCards("KsKd")},
+				Board: parseCards("6c7d8h"),
+				GameConfig: game.NewShortDeckConfig(),
+				Exhaustive: true,
+			}
+			runouts, err := RunoutsCount(config)
+			require.NoError(t, err)
+			// 36 - 7 known cards, two cards to come
+			require.Equal(t, 406, runouts)
+
+			config.Board = parseCards("6c7d8hKc")
+			odds, err := HandOdds(config)
+			require.NoError(t, err)
+			require.Equal(t, 28, odds.IterationsCount())
+		})
+
+		t.Run("As9h vs KsKd, board: 6c7d8hKc", func(t *testing.T) {
+			// A6789 is a straight only in short-deck: hero wins unless river gives KsKd a full house or quads
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("As9h"), parseCards("KsKd")},
+				Board: parseCards("6c7d8hKc"),
+				GameConfig: game.NewShortDeckConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+
+			wins, err := odds.AllPlayerWins()
+			require.NoError(t, err)
+			require.Equal(t, []int{18, 10}, wins)
+		})
+
+		t.Run("AsQs vs KhKd, board: Ks7s6s7d", func(t *testing.T) {
+			// flush beats full house in short-deck, only Kc saves KhKd
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsQs"), parseCards("KhKd")},
+				Board: parseCards("Ks7s6s7d"),
+				GameConfig: game.NewShortDeckConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+
+			wins, err := odds.AllPlayerWins()
+			require.NoError(t, err)
+			require.Equal(t, []int{27, 1}, wins)
+		})
+
+		t.Run("range combos outside of deck are never dealt", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Ranges: []cards.Range{rangeOf(1, "As2s", "AhKh"), rangeOf(1, "QdQc")},
+				IterationsCount: 100,
+				GameConfig: game.NewShortDeckConfig(),
+			})
+			require.NoError(t, err)
+
+			combos, err := odds.ComboEquities(0)
+			require.NoError(t, err)
+			require.Equal(t, 0, combos[0].IterationsCount)
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		invalid := []struct{
+			name string
+			hands []string
+			board string
+		}{
+			{name: "hand card outside of deck", hands: []string{"As2h", "KsKd"}, board: "6c7d8h"},
+			{name: "board card outside of deck", hands: []string{"As9h", "KsKd"}, board: "6c7d5h"},
+			{name: "card used twice", hands: []string{"As9h", "KsAs"}, board: "6c7d8h"},
+		}
+
+		for _, testCase := range invalid {
+			t.Run(testCase.name, func(t *testing.T) {
+				config := HandOddsConfig{
+					Hands: lo.Map(testCase.hands, func(hand string, _ int) []cards.Card { return parseCards(hand) }),
+					Board: parseCards(testCase.board),
+					IterationsCount: 100,
+					GameConfig: game.NewShortDeckConfig(),
+				}
+
+				odds, err := HandOdds(config)
+				require.Error(t, err)
+				require.Nil(t, odds)
+
+				config.Exhaustive = true
+				odds, err = HandOdds(config)
+				require.Error(t, err)
+				require.Nil(t, odds)
+			})
+		}
+	})
+}
+
+func TestHandOdds_DeadCards(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("dead ace leaves AsAd a single out", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				Board: parseCards("Kh7c2d5s"),
+				DeadCards: parseCards("Ac"),
+				GameConfig: game.NewTexasConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, 43, odds.IterationsCount())
+
+			wins, err := odds.AllPlayerWins()
+			require.NoError(t, err)
+			require.Equal(t, []int{1, 42}, wins)
+		})
+
+		t.Run("dead cards are never dealt", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				Board: parseCards("Kh7c2d5s"),
+				DeadCards: parseCards("AcAh"),
+				IterationsCount: 1000,
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+
+			wins, err := odds.AllPlayerWins()
+			require.NoError(t, err)
+			require.Equal(t, 0, wins[0])
+		})
+
+		t.Run("range combos with dead cards are never dealt", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Ranges: []cards.Range{rangeOf(1, "AsKs", "AhKh"), rangeOf(1, "QdQc")},
+				DeadCards: parseCards("Ks"),
+				IterationsCount: 100,
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+
+			combos, err := odds.ComboEquities(0)
+			require.NoError(t, err)
+			require.Equal(t, 0, combos[0].IterationsCount)
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		invalid := []struct{
+			name string
+			deadCards string
+			gameConfig game.Config
+		}{
+			{name: "dead card in hand", deadCards: "As", gameConfig: game.NewTexasConfig()},
+			{name: "dead card on board", deadCards: "Kh", gameConfig: game.NewTexasConfig()},
+			{name: "dead card used twice", deadCards: "AcAc", gameConfig: game.NewTexasConfig()},
+			{name: "dead card outside of deck", deadCards: "2c", gameConfig: game.NewShortDeckConfig()},
+		}
+
+		for _, testCase := range invalid {
+			t.Run(testCase.name, func(t *testing.T) {
+				odds, err := HandOdds(HandOddsConfig{
+					Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+					Board: parseCards("Kh7c8d"),
+					DeadCards: parseCards(testCase.deadCards),
+					IterationsCount: 100,
+					GameConfig: testCase.gameConfig,
+				})
+				require.Error(t, err)
+				require.Nil(t, odds)
+			})
+		}
+	})
+}
+
+func Test_runoutStride(t *testing.T) {
+	for _, runouts := range []int{1, 2, 10, 44, 990, 1081} {
+		stride := runoutStride(runouts)
+		visited := lo.Uniq(lo.Times(runouts, func(job int) int {
+			return runoutIndex(job, stride, runouts)
+		}))
+		require.Equal(t, runouts, len(visited))
+	}
+}
+
+func Test_runoutIndex(t *testing.T) {
+	// preflop runouts of a single hand, job * stride doesn't fit 32-bit int
+	runouts := 2118760
+	stride := runoutStride(runouts)
+
+	for _, job := range []int{0, 1, runouts / 2, runouts - 1} {
+		index := runoutIndex(job, stride, runouts)
+		require.GreaterOrEqual(t, index, 0)
+		require.Less(t, index, runouts)
+		require.Equal(t, int64(job) * int64(stride) % int64(runouts), int64(index))
+	}
+}
@@ -1,14 +1,16 @@
 package calc
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"sort"
+	"math/rand"
+	"sync"
 
 	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/evaluator"
 	"github.com/anuarkaliyev23/goker/pkg/game"
 	"github.com/samber/lo"
-	"gonum.org/v1/gonum/stat/combin"
 )
 
 const CardsUsedInTexasHoldem = 7
@@ -16,16 +18,133 @@ const BurnCardsCount = 3
 const CommunityCardsCount = 5
 const HoleCardsCount = 2
 
+// Exhaustive enumeration falls back to sampling when there are more runouts than this
+const DefaultExhaustiveThreshold = 50000
+
 type HandOddsConfig struct {
+	// Known hole cards of every player. Hand with less than GameConfig.HoleCardsCount cards
+	// is completed with random cards on every iteration, so empty hand is a random opponent
 	Hands [][]cards.Card
+	// Per-player ranges, used instead of Hands if set. Hands from ranges are always sampled
+	Ranges []cards.Range
 	Board []cards.Card
+	// Folded or exposed cards, which are removed from the deck before dealing
+	DeadCards []cards.Card
+	// Number of iterations to simulate. In games with discards it is simulated for each of C(HoleCardsCount, DiscardsCount)
+	// options of every player deciding their discard, and once more for the chosen discards
 	IterationsCount int
 	GameConfig game.Config
+
+	Exhaustive bool
+	ExhaustiveThreshold int
+
+	// Number of workers simulation is split between, defaults to GOMAXPROCS
+	Threads int
+
+	// Retain every simulated iteration in HandOddsResult.Iterations, only tallies are kept otherwise
+	KeepIterations bool
+
+	// Makes simulation repeatable if set, regardless of Threads
+	Seed *int64
+
+	// If set, sampling continues until margin of error of every player's equity at 95% confidence is within Precision.
+	// IterationsCount is used as the size of the first round
+	Precision float64
+	// Caps sampling in Precision mode, defaults to DefaultMaxIterationsCount
+	MaxIterationsCount int
+
+	// Draws each player takes before showdown in draw games, Hands are then the cards players keep on the next draw.
+	// Replacements are dealt from the deck, later draws are played by a simple strategy, see keepDrawnCards
+	Draws []int
+
+	// Face up cards of every player in games with them, see game.Config.UpCardsCount. Hands are then player's down cards.
+	// Every player must show the same number of up cards, they are joined to Hands before simulation
+	UpCards [][]cards.Card
+
+	// Cards each player discards in games with discards, empty for players whose discard is not chosen.
+	// They discard for the best equity if their hand and the board at the time of discard are known,
+	// otherwise by a simple strategy, see keptAtDiscard. Games with discards are always sampled.
+	// Every deciding player multiplies the cost by the number of their options, C(HoleCardsCount, DiscardsCount)
+	Discards [][]cards.Card
+}
+
+func (c HandOddsConfig) PlayersCount() int {
+	if len(c.Ranges) > 0 {
+		return len(c.Ranges)
+	}
+	return len(c.Hands)
+}
+
+// HasUnknownCards reports whether some of the hands are not fully known
+func (c HandOddsConfig) HasUnknownCards() bool {
+	return lo.SomeBy(c.Hands, func(hand []cards.Card) bool {
+		return len(hand) < c.GameConfig.HoleCardsCount
+	})
+}
+
+func (c HandOddsConfig) newAccumulator() HandOddsAccumulator {
+	accumulator := NewHandOddsAccumulator(c.PlayersCount())
+	if len(c.Ranges) > 0 {
+		accumulator.trackCombos(lo.Map(c.Ranges, func(r cards.Range, _ int) int {
+			return len(r)
+		}))
+	}
+	return accumulator
+}
+
+func (c HandOddsConfig) exhaustiveThreshold() int {
+	if c.ExhaustiveThreshold <= 0 {
+		return DefaultExhaustiveThreshold
+	}
+	return c.ExhaustiveThreshold
 }
 
 type HandOddsIteration struct {
 	Combinations []cards.Combination
+	// Comparable strength of each combination, used instead of comparing combinations when present
+	Ranks []evaluator.Rank
+	// Ace-to-five low rank of each player, set only for hi-lo games. Zero if player has no qualifying low
+	LowRanks []evaluator.LowRank
 	Board []cards.Card
+	// Index of the combo each player was dealt from their range, set only for simulations over ranges
+	HandIndexes []int
+}
+
+func newHandOddsIteration(hands [][]cards.Card, board []cards.Card, extraCommunityCards []cards.Card, gameConfig game.Config) HandOddsIteration {
+	ranks := make([]evaluator.Rank, len(hands))
+	combinations := make([]cards.Combination, len(hands))
+	for i, hand := range hands {
+		ranks[i], combinations[i] = evaluateHand(hand, board, extraCommunityCards, gameConfig)
+	}
+
+	var lowRanks []evaluator.LowRank
+	if gameConfig.HiLo {
+		lowRanks = lo.Map(hands, func(hand []cards.Card, _ int) evaluator.LowRank {
+			return lowRankHand(hand, board, extraCommunityCards, gameConfig)
+		})
+	}
+
+	return HandOddsIteration{
+		Combinations: combinations,
+		Ranks: ranks,
+		LowRanks: lowRanks,
+		Board: append(append([]cards.Card{}, board...), extraCommunityCards...),
+	}
+}
+
+func (r HandOddsIteration) isHiLo() bool {
+	return len(r.LowRanks) > 0 && len(r.LowRanks) == len(r.Combinations)
+}
+
+func (r HandOddsIteration) hasRanks() bool {
+	return len(r.Ranks) > 0 && len(r.Ranks) == len(r.Combinations)
+}
+
+func (r HandOddsIteration) combinationType(player int) cards.CombinationType {
+	if r.hasRanks() {
+		return r.Ranks[player].Type()
+	}
+	return r.Combinations[player].Type()
 }
 
 func (r HandOddsIteration) StrongestCombination() (*cards.Combination, error) {
@@ -43,6 +162,17 @@ func (r HandOddsIteration) StrongestCombination() (*cards.Combination, error) {
 }
 
 func (r HandOddsIteration) playersWithStrongestCombinations() ([]int, error) {
+	if r.hasRanks() {
+		maxRank := lo.Max(r.Ranks)
+		winners := []int{}
+		for i, rank := range r.Ranks {
+			if rank == maxRank {
+				winners = append(winners, i)
+			}
+		}
+		return winners, nil
+	}
+
 	winner, err := r.StrongestCombination()
 	if err != nil {
 		return nil, err
@@ -59,8 +189,113 @@ func (r HandOddsIteration) playersWithStrongestCombinations() ([]int, error) {
 	return winners, nil
 }
 
+// playersWithBestLow returns players with the best qualifying low, empty if nobody qualified or game is not hi-lo
+func (r HandOddsIteration) playersWithBestLow() []int {
+	if !r.isHiLo() {
+		return []int{}
+	}
+
+	bestLow := lo.Max(r.LowRanks)
+	if bestLow == 0 {
+		return []int{}
+	}
+
+	winners := []int{}
+	for i, rank := range r.LowRanks {
+		if rank == bestLow {
+			winners = append(winners, i)
+		}
+	}
+	return winners
+}
+
+// Winners returns every player sharing the pot, including both halves of it in hi-lo games
+func (r HandOddsIteration) Winners() ([]int, error) {
+	shares, err := r.PotShares()
+	if err != nil {
+		return nil, err
+	}
+
+	winners := []int{}
+	for i, share := range shares {
+		if share > 0 {
+			winners = append(winners, i)
+		}
+	}
+	return winners, nil
+}
+
+// halfShares returns part of the high and of the low half every player got, 1/k for each of k players splitting a half.
+// Low parts are all zero if nobody has a qualifying low
+func (r HandOddsIteration) halfShares() ([]float64, []float64, error) {
+	highWinners, err := r.playersWithStrongestCombinations()
+	if err != nil {
+		return nil, nil, err
+	}
+	lowWinners := r.playersWithBestLow()
+
+	high := make([]float64, len(r.Combinations))
+	for _, winner := range highWinners {
+		high[winner] = 1 / float64(len(highWinners))
+	}
+
+	low := make([]float64, len(r.Combinations))
+	for _, winner := range lowWinners {
+		low[winner] = 1 / float64(len(lowWinners))
+	}
+	return high, low, nil
+}
+
+// PotShares returns part of the pot every player gets, 1/k for each of k players splitting the pot.
+// In hi-lo games each half is split separately, high hands take the whole pot if nobody has a qualifying low
+func (r HandOddsIteration) PotShares() ([]float64, error) {
+	high, low, err := r.halfShares()
+	if err != nil {
+		return nil, err
+	}
+
+	highPot := 1.0
+	if lo.Sum(low) > 0 {
+		highPot = 0.5
+	}
+
+	return lo.Map(high, func(highShare float64, player int) float64 {
+		return highShare * highPot + low[player] * 0.5
+	}), nil
+}
+
+// SplitOutcomes returns how each player did in a hi-lo pot, or -1 for players who got nothing
+func (r HandOddsIteration) SplitOutcomes() ([]SplitOutcome, error) {
+	high, low, err := r.halfShares()
+	if err != nil {
+		return nil, err
+	}
+	hasLow := lo.Sum(low) > 0
+
+	return lo.Times(len(r.Combinations), func(player int) SplitOutcome {
+		highShare, lowShare := high[player], low[player]
+		switch {
+		case highShare == 0 && lowShare == 0:
+			return -1
+		case highShare == 1 && (lowShare == 1 || !hasLow):
+			return Scoop
+		case (highShare == 1 && lowShare > 0) || (lowShare == 1 && highShare > 0):
+			return ThreeQuarters
+		case highShare == 1:
+			return HighOnly
+		case lowShare == 1:
+			return LowOnly
+		case !hasLow || (highShare > 0 && lowShare > 0):
+			return Chopped
+		default:
+			return Quartered
+		}
+	}), nil
+}
+
+// Winner returns the only player who won the pot, or -1 if it was split
 func (r HandOddsIteration) Winner() (int, error) {
-	winners, err := r.playersWithStrongestCombinations()
+	winners, err := r.Winners()
 	if err != nil {
 		return -1, err
 	}
@@ -72,6 +307,7 @@ func (r HandOddsIteration) Winner() (int, error) {
 	return winners[0], nil
 }
 
+// IsTie is true only if every player split the pot, see Winners for partial splits
 func (r HandOddsIteration) IsTie() (bool, error) {
 	winner, err := r.Winner()
 	if err != nil {
@@ -82,7 +318,7 @@ func (r HandOddsIteration) IsTie() (bool, error) {
 		return false, nil
 	}
 
-	winners, err := r.playersWithStrongestCombinations()
+	winners, err := r.Winners()
 	if err != nil {
 		return false, err
 	}
@@ -96,20 +332,26 @@ func (r HandOddsIteration) IsTie() (bool, error) {
 
 type HandOddsResult struct {
 	Config HandOddsConfig
+	Accumulator HandOddsAccumulator
+	// Raw iterations, only retained if Config.KeepIterations is set
 	Iterations []HandOddsIteration
+
+	// true if every possible runout was enumerated instead of sampled
+	Exhaustive bool
+	// true if calculation was stopped by context before all iterations were done
+	Interrupted bool
+}
+
+func (r HandOddsResult) IterationsCount() int {
+	return r.Accumulator.IterationsCount
 }
 
 func (r HandOddsResult) PlayerWins(index int) (int, error) {
-	winners := lo.Map(r.Iterations, func(iteration HandOddsIteration, _ int) int {
-		w, _ := iteration.Winner()
-		return w
-	})
+	if index < 0 || index >= r.Accumulator.PlayersCount() {
+		return 0, fmt.Errorf("Player {%d} is out of range, number of players: {%d}", index, r.Accumulator.PlayersCount())
+	}
 
-	wins := lo.CountBy(winners, func(player int) bool {
-		return index == player
-	})
-	
-	return wins, nil
+	return r.Accumulator.Wins[index], nil
 }
 
 func (r HandOddsResult) AllPlayerWins() ([]int, error) {
@@ -132,7 +374,7 @@ func (r HandOddsResult) WinRates() ([]float32, error) {
 	}
 
 	winRates := lo.Map(wins, func(w int, _ int) float32 {
-		return float32(w) / float32(r.Config.IterationsCount)
+		return float32(w) / float32(r.IterationsCount())
 	})
 
 	return winRates, nil
@@ -144,7 +386,7 @@ func (r HandOddsResult) TiePercentage() (float32, error) {
 		return 0.0, err
 	}
 
-	return float32(ties) / float32(r.Config.IterationsCount), nil
+	return float32(ties) / float32(r.IterationsCount()), nil
 }
 
 func (r HandOddsResult) WinningPlayer() (int, error) {
@@ -159,24 +401,95 @@ func (r HandOddsResult) WinningPlayer() (int, error) {
 
 
 func (r HandOddsResult) Ties() (int, error) {
-	toTies := lo.Map(r.Iterations, func(iteration HandOddsIteration, _ int) bool {
-		tie, _ := iteration.IsTie()
-		return tie
+	return r.Accumulator.Ties, nil
+}
+
+func (r HandOddsResult) NumberOfPlayers() int {
+	return r.Config.PlayersCount()
+}
+
+// TieRates returns how often each player split the pot with at least one other player
+func (r HandOddsResult) TieRates() []float64 {
+	return lo.Map(r.Accumulator.PlayerTies, func(ties int, _ int) float64 {
+		return float64(ties) / float64(r.IterationsCount())
 	})
+}
 
-	ties := lo.Count(toTies, true)
-	return ties, nil
+// Equities returns share of the pot each player is expected to win, split pots are counted as 1/k for k-way split
+func (r HandOddsResult) Equities() []float64 {
+	return lo.Map(r.Accumulator.Shares, func(shares float64, _ int) float64 {
+		return shares / float64(r.IterationsCount())
+	})
 }
 
-func (r HandOddsResult) NumberOfPlayers() int {
-	return len(r.Config.Hands)
+type ComboEquity struct {
+	Hand []cards.Card
+	Weight float64
+	// How many iterations the combo was dealt in
+	IterationsCount int
+	Equity float64
 }
 
-func (r HandOddsResult) PlayerHand(index int) ([]cards.Card, error) {
-	if index > r.NumberOfPlayers() {
+// ComboEquities returns equity breakdown for every combo of player's range
+func (r HandOddsResult) ComboEquities(index int) ([]ComboEquity, error) {
+	if index < 0 || index >= len(r.Config.Ranges) {
+		return nil, fmt.Errorf("Player {%d} has no range, number of ranges: {%d}", index, len(r.Config.Ranges))
+	}
+
+	return lo.Map(r.Config.Ranges[index], func(hand cards.WeightedHand, combo int) ComboEquity {
+		dealt := r.Accumulator.ComboDealt[index][combo]
+		equity := 0.0
+		if dealt > 0 {
+			equity = r.Accumulator.ComboShares[index][combo] / float64(dealt)
+		}
+
+		return ComboEquity{
+			Hand: hand.Cards,
+			Weight: hand.Weight,
+			IterationsCount: dealt,
+			Equity: equity,
+		}
+	}), nil
+}
+
+type CombinationStat struct {
+	CombinationType cards.CombinationType
+	// Share of iterations player finished with the combination type
+	Frequency float64
+	// Share of those iterations player won without splitting the pot
+	WinRate float64
+	// Part of player's equity made by the combination type
+	Equity float64
+}
+
+// CombinationStats summarises combinations player finished with, from high card to straight flush
+func (r HandOddsResult) CombinationStats(index int) ([]CombinationStat, error) {
+	if index < 0 || index >= r.NumberOfPlayers() {
 		return nil, fmt.Errorf("Player {%d} is out of range, number of players: {%d}", index, r.NumberOfPlayers())
 	}
 
+	iterations := float64(r.IterationsCount())
+	return lo.Times(combinationTypesCount, func(ctype int) CombinationStat {
+		finished := r.Accumulator.CombinationTypes[index][ctype]
+		winRate := 0.0
+		if finished > 0 {
+			winRate = float64(r.Accumulator.CombinationWins[index][ctype]) / float64(finished)
+		}
+
+		return CombinationStat{
+			CombinationType: cards.CombinationType(ctype),
+			Frequency: float64(finished) / iterations,
+			WinRate: winRate,
+			Equity: r.Accumulator.CombinationShares[index][ctype] / iterations,
+		}
+	}), nil
+}
+
+func (r HandOddsResult) PlayerHand(index int) ([]cards.Card, error) {
+	if index >= len(r.Config.Hands) {
+		return nil, fmt.Errorf("Player {%d} is out of range, number of players with known hands: {%d}", index, len(r.Config.Hands))
+	}
+
 	return r.Config.Hands[index], nil
 }
 
@@ -184,6 +497,10 @@ func (r HandOddsResult) PlayerCombinations(index int) ([]cards.Combination, erro
 	if index > r.NumberOfPlayers() {
 		return nil, fmt.Errorf("Player {%d} is out of range, number of players: {%d}", index, r.NumberOfPlayers())
 	}
+
+	if len(r.Iterations) != r.IterationsCount() {
+		return nil, errors.New("Iterations were not retained, set HandOddsConfig.KeepIterations to access combinations")
+	}
 	
 	combinations := lo.Map(r.Iterations, func(iteration HandOddsIteration, _ int) cards.Combination {
 		return iteration.Combinations[index]
@@ -193,29 +510,85 @@ func (r HandOddsResult) PlayerCombinations(index int) ([]cards.Combination, erro
 }
 
 func HandOdds(config HandOddsConfig) (*HandOddsResult, error) {
-	if config.IterationsCount <= 0 {
-		return nil, fmt.Errorf("Cannot simulate hands odds for non-positive or zero iterations, was given {%d}", config.IterationsCount)
+	return HandOddsContext(context.Background(), config)
+}
+
+// HandOddsContext stops on cancellation or deadline of ctx and returns result of iterations done so far,
+// marked as interrupted. It is an error only if not a single iteration was done
+func HandOddsContext(ctx context.Context, config HandOddsConfig) (*HandOddsResult, error) {
+	result, err := handOdds(ctx, config)
+	if err != nil {
+		return nil, err
 	}
-	
-	iterations := []HandOddsIteration{}
-	
-	for i := 0; i < config.IterationsCount; i++ {
-		iteration, err := iterate(config.Hands, config.Board, config.GameConfig)
+
+	if result.IterationsCount() == 0 {
+		return nil, fmt.Errorf("Calculation was interrupted before the first iteration: %w", ctx.Err())
+	}
+	return result, nil
+}
+
+func handOdds(ctx context.Context, config HandOddsConfig) (*HandOddsResult, error) {
+	config, err := joinUpCards(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.GameConfig.DiscardsCount > 0 {
+		if config.IterationsCount <= 0 {
+			return nil, fmt.Errorf("Cannot simulate hands odds for non-positive or zero iterations, was given {%d}", config.IterationsCount)
+		}
+		return discardOdds(ctx, config)
+	}
+
+	if len(config.Ranges) > 0 {
+		if len(config.Hands) > 0 {
+			return nil, errors.New("Cannot simulate hand odds for both hands and ranges, pass known hands as single combo ranges")
+		}
+		if config.IterationsCount <= 0 {
+			return nil, fmt.Errorf("Cannot simulate hands odds for non-positive or zero iterations, was given {%d}", config.IterationsCount)
+		}
+		return rangeOdds(ctx, config)
+	}
+
+	if len(config.Draws) > 0 {
+		if config.IterationsCount <= 0 {
+			return nil, fmt.Errorf("Cannot simulate hands odds for non-positive or zero iterations, was given {%d}", config.IterationsCount)
+		}
+		return drawGameOdds(ctx, config)
+	}
+
+	if config.Exhaustive && !config.HasUnknownCards() {
+		runouts, err := RunoutsCount(config)
 		if err != nil {
-			return nil, err 
+			return nil, err
+		}
+
+		if runouts <= config.exhaustiveThreshold() {
+			return enumerate(ctx, config)
 		}
-		
-		iterations = append(iterations, *iteration)
+	}
+
+	if config.IterationsCount <= 0 {
+		return nil, fmt.Errorf("Cannot simulate hands odds for non-positive or zero iterations, was given {%d}", config.IterationsCount)
 	}
 	
-	return &HandOddsResult{
-		Config: config,
-		Iterations: iterations,
-	}, nil
+	err = validateIteration(config.Hands, config.Board, config.GameConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateCards(config.Hands, config.Board, config.DeadCards, config.GameConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return sample(ctx, config, func(random *rand.Rand, _ int) (*HandOddsIteration, error) {
+		return iterate(config.Hands, config.Board, config.DeadCards, config.GameConfig, random)
+	})
 }
 
-func collectExcludedCards(board []cards.Card, hands [][]cards.Card) []cards.Card {
-	excludedCards := board
+func collectExcludedCards(board []cards.Card, hands [][]cards.Card, deadCards []cards.Card) []cards.Card {
+	excludedCards := append(append([]cards.Card{}, board...), deadCards...)
 
 	lo.ForEach(hands, func(hand []cards.Card , _ int) {
 		excludedCards = append(excludedCards, hand...)
@@ -225,17 +598,44 @@ func collectExcludedCards(board []cards.Card, hands [][]cards.Card) []cards.Card
 }
 
 func excludeCards(deck cards.Deck, excludedCards []cards.Card) cards.Deck {
-	lo.ForEach(excludedCards, func(card cards.Card, _ int) {
-		err := deck.MoveToDrawn(card)
-		if err != nil {
-			// This should never happen
-			panic(err)
-		}
-	})
+	err := deck.MoveAllToDrawn(excludedCards)
+	if err != nil {
+		// This should never happen
+		panic(err)
+	}
 
 	return deck
 }
 
+// validateCards checks that the game is playable and that every known card belongs to the game deck and is not used twice
+func validateCards(hands [][]cards.Card, board []cards.Card, deadCards []cards.Card, gameConfig game.Config) error {
+	err := gameConfig.Validate()
+	if err != nil {
+		return err
+	}
+
+	deck := gameConfig.NewDeck()
+	knownCards := collectExcludedCards(board, hands, deadCards)
+
+	for _, card := range knownCards {
+		if !deck.ContainsCard(card) {
+			return fmt.Errorf("Card {%v} does not belong to the deck of the game", card)
+		}
+	}
+
+	duplicates := lo.FindDuplicates(knownCards)
+	if len(duplicates) > 0 {
+		return fmt.Errorf("Cards {%v} are used more than once", duplicates)
+	}
+
+	requiredCards := len(hands) * gameConfig.HoleCardsCount + gameConfig.CommunityCardsCount + len(deadCards)
+	if requiredCards > len(deck.LeftCards()) {
+		return fmt.Errorf("Deck of {%d} cards is not enough to deal {%d} cards", len(deck.LeftCards()), requiredCards)
+	}
+
+	return nil
+}
+
 func validateIteration(hands [][]cards.Card, board []cards.Card, gameConfig game.Config) error {
 	if len(hands) > gameConfig.MaxPlayers {
 		return fmt.Errorf("Too many players {%d}, should be {%d}", len(hands), gameConfig.MaxPlayers)
@@ -250,12 +650,33 @@ func validateIteration(hands [][]cards.Card, board []cards.Card, gameConfig game
 	}
 
 	if containedInvalidHands {
-		return fmt.Errorf("Cannot construct iteration for hand of invalid size, should be less than {%d}", gameConfig.HoleCardsCount)
+		return fmt.Errorf("Cannot construct iteration for hand of invalid size, should be at most {%d}", gameConfig.HoleCardsCount)
 	}
 
 	return nil
 }
 
+// dealUnknownCards completes every partially known hand with cards drawn from deck
+func dealUnknownCards(deck cards.Deck, hands [][]cards.Card, holeCardsCount int) (cards.Deck, [][]cards.Card) {
+	dealtHands := lo.Map(hands, func(hand []cards.Card, _ int) []cards.Card {
+		if len(hand) >= holeCardsCount {
+			return hand
+		}
+
+		dealtHand := append(make([]cards.Card, 0, holeCardsCount), hand...)
+		for len(dealtHand) < holeCardsCount {
+			drawnCard, err := deck.Draw()
+			if err != nil {
+				//This should never happen
+				panic(err)
+			}
+			dealtHand = append(dealtHand, *drawnCard)
+		}
+		return dealtHand
+	})
+	return deck, dealtHands
+}
+
 func drawCommunityCards(deck cards.Deck, board []cards.Card, maxCommunityCards int) (cards.Deck, []cards.Card) {
 	cardsToDraw := maxCommunityCards - len(board)
 	drawnCards := []cards.Card{}
@@ -270,101 +691,233 @@ func drawCommunityCards(deck cards.Deck, board []cards.Card, maxCommunityCards i
 	return deck, drawnCards
 }
 
+func gameEvaluator(gameConfig game.Config) evaluator.Evaluator {
+	if gameConfig.Ranking == cards.DeuceToSevenRanking {
+		return evaluator.DeuceToSeven
+	}
+	if len(gameConfig.CombinationStrengths) > 0 {
+		return customEvaluator(gameConfig.CombinationStrengths, gameConfig.ShortDeck)
+	}
+	if gameConfig.ShortDeck {
+		return evaluator.ShortDeck
+	}
+	return evaluator.Default
+}
+
+type customEvaluatorKey struct {
+	strengths [cards.StraightFlush + 1]cards.CombinationType
+	shortDeck bool
+}
+
+// Evaluators of custom combination strengths, constructed once per order
+var customEvaluators sync.Map
+
+func customEvaluator(strengths []cards.CombinationType, shortDeck bool) evaluator.Evaluator {
+	key := customEvaluatorKey{shortDeck: shortDeck}
+	copy(key.strengths[:], strengths)
+
+	if e, ok := customEvaluators.Load(key); ok {
+		return e.(evaluator.Evaluator)
+	}
+
+	e, _ := customEvaluators.LoadOrStore(key, evaluator.NewEvaluator(strengths, shortDeck))
+	return e.(evaluator.Evaluator)
+}
+
+// gameStrengths returns combination types of the game from the weakest to the strongest
+func gameStrengths(gameConfig game.Config) []cards.CombinationType {
+	if gameConfig.Ranking == cards.AceToFiveRanking {
+		return cards.AceToFiveCombinationStrength
+	}
+	if gameConfig.Ranking == cards.DeuceToSevenRanking {
+		return cards.DeuceToSevenCombinationStrength
+	}
+	if len(gameConfig.CombinationStrengths) > 0 {
+		return gameConfig.CombinationStrengths
+	}
+	if gameConfig.ShortDeck {
+		return cards.ShortDeckCombinationStrength
+	}
+	return cards.DefaultCombinationStrength
+}
+
+func gameCombination(cs []cards.Card, gameConfig game.Config) cards.Combination {
+	if gameConfig.Ranking != cards.HighRanking {
+		combination, err := cards.NewLowballCombination(cs, gameConfig.Ranking)
+		if err != nil {
+			//This should never happen
+			panic(err)
+		}
+		return *combination
+	}
+
+	combination, err := cards.NewCombination(cs, gameStrengths(gameConfig), gameConfig.ShortDeck)
+	if err != nil {
+		//This should never happen
+		panic(err)
+	}
+	return *combination
+}
+
 func strongestHandCombination(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card, gameConfig game.Config) cards.Combination {
-	if gameConfig.Game == game.ShortDeck || gameConfig.Game == game.Texas {
-		return strongestHandCombinationsDefault(hand, board, extraCommunityCards, gameConfig)
-	} else if gameConfig.Game == game.Omaha {
-		return strongestHandCombinationOmaha(hand, board, extraCommunityCards, gameConfig)
+	_, combination := evaluateHand(hand, board, extraCommunityCards, gameConfig)
+	return combination
+}
+
+// evaluateHand returns comparable rank of the strongest player combination along with the combination itself
+func evaluateHand(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card, gameConfig game.Config) (evaluator.Rank, cards.Combination) {
+	rank, combinationCards := rankHand(hand, board, extraCommunityCards, gameConfig)
+	return rank, gameCombination(combinationCards, gameConfig)
+}
+
+// rankHand returns comparable rank of the strongest player combination along with cards it consists of
+func rankHand(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card, gameConfig game.Config) (evaluator.Rank, []cards.Card) {
+	splits := usableSplits(len(hand), len(board) + len(extraCommunityCards), gameConfig)
+	if splits != nil {
+		return rankHandSplits(hand, board, extraCommunityCards, splits, gameConfig)
+	} else if gameConfig.Ranking == cards.AceToFiveRanking {
+		return rankHandAceToFive(hand, board, extraCommunityCards)
 	} else {
-		panic("Unrecognized game configuration")
+		return rankHandDefault(hand, board, extraCommunityCards, gameConfig)
 	}
 }
 
-func strongestHandCombinationsDefault(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card, gameConfig game.Config) cards.Combination {
+// usableSplits returns every pair of hole and board cards counts a hand can be made of according to
+// HoleCardsAllowedToUseCount and CommunityCardsAllowedToUseCount, or nil if any five of the cards can be used
+func usableSplits(handSize int, boardSize int, gameConfig game.Config) [][2]int {
+	if gameConfig.MustUseExactly {
+		return [][2]int{{gameConfig.HoleCardsAllowedToUseCount, gameConfig.CommunityCardsAllowedToUseCount}}
+	}
+
+	holeLimit := min(handSize, gameConfig.HoleCardsAllowedToUseCount)
+	boardLimit := min(boardSize, gameConfig.CommunityCardsAllowedToUseCount)
+	if holeLimit >= min(handSize, cards.CombinationLength) && boardLimit >= min(boardSize, cards.CombinationLength) {
+		return nil
+	}
+
+	splits := [][2]int{}
+	for holeCount := max(0, cards.CombinationLength - boardLimit); holeCount <= holeLimit; holeCount++ {
+		splits = append(splits, [2]int{holeCount, cards.CombinationLength - holeCount})
+	}
+	return splits
+}
+
+func rankHandDefault(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card, gameConfig game.Config) (evaluator.Rank, []cards.Card) {
 	usedCards := []cards.Card{}
 	usedCards = append(usedCards, hand...)
 	usedCards = append(usedCards, board...)
 	usedCards = append(usedCards, extraCommunityCards...)
-	
-	shortDeck := gameConfig.Game == game.ShortDeck
 
-	if shortDeck {
-		combination, err := cards.StrongestCombinationOf(usedCards, cards.ShortDeckCombinationStrength, true)
-		if err != nil {
-			//This should never happen
-			panic(err)
+	rank, subset, err := gameEvaluator(gameConfig).Strongest(evaluator.NewCards(usedCards))
+	if err != nil {
+		//This should never happen
+		panic(err)
+	}
+
+	combinationCards := lo.Map(subset, func(cardIndex int, _ int) cards.Card {
+		return usedCards[cardIndex]
+	})
+	return rank, combinationCards
+}
+
+// rankHandAceToFive ranks the best ace-to-five low of any five cards, as in Razz
+func rankHandAceToFive(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card) (evaluator.Rank, []cards.Card) {
+	usedCards := append(append(append([]cards.Card{}, hand...), board...), extraCommunityCards...)
+
+	rank, subset, err := evaluator.Low(evaluator.NewCards(usedCards))
+	if err != nil {
+		//This should never happen
+		panic(err)
+	}
+
+	combinationCards := lo.Map(subset, func(cardIndex int, _ int) cards.Card {
+		return usedCards[cardIndex]
+	})
+	return rank.Rank(), combinationCards
+}
+
+// rankHandSplits ranks the best hand made of exactly given numbers of hole and board cards, trying every split
+func rankHandSplits(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card, splits [][2]int, gameConfig game.Config) (evaluator.Rank, []cards.Card) {
+	board = append(append([]cards.Card{}, board...), extraCommunityCards...)
+
+	var best evaluator.Rank
+	var bestCards []cards.Card
+	for _, split := range splits {
+		var rank evaluator.Rank
+		var holeSubset, boardSubset []int
+		var err error
+		if gameConfig.Ranking == cards.AceToFiveRanking {
+			var lowRank evaluator.LowRank
+			lowRank, holeSubset, boardSubset, err = evaluator.LowOf(evaluator.NewCards(hand), split[0], evaluator.NewCards(board), split[1])
+			rank = lowRank.Rank()
+		} else {
+			rank, holeSubset, boardSubset, err = gameEvaluator(gameConfig).StrongestOf(evaluator.NewCards(hand), split[0], evaluator.NewCards(board), split[1])
 		}
-		return *combination
-	} else {
-		combination, err := cards.StrongestCombinationOf(usedCards, cards.DefaultCombinationStrength, false)
 		if err != nil {
 			//This should never happen
 			panic(err)
 		}
-		return *combination
+
+		if bestCards != nil && rank <= best {
+			continue
+		}
+
+		best = rank
+		bestCards = make([]cards.Card, 0, len(holeSubset) + len(boardSubset))
+		for _, cardIndex := range holeSubset {
+			bestCards = append(bestCards, hand[cardIndex])
+		}
+		for _, cardIndex := range boardSubset {
+			bestCards = append(bestCards, board[cardIndex])
+		}
 	}
+	return best, bestCards
 }
 
-func strongestHandCombinationOmaha(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card, gameConfig game.Config) cards.Combination {
-	board = append(board, extraCommunityCards...)
+// lowRankHand returns rank of the best ace-to-five low of the player, or zero if it doesn't qualify as eight-or-better
+func lowRankHand(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card, gameConfig game.Config) evaluator.LowRank {
+	board = append(append([]cards.Card{}, board...), extraCommunityCards...)
 
-	handCombinations := combin.Combinations(len(hand), gameConfig.HoleCardsAllowedToUseCount)
-	boardCombinations := combin.Combinations(len(board), gameConfig.CommunityCardsAllowedToUseCount)
-	
-	handCardsCombinations := mapIndexToCards(hand, handCombinations)
-	boardCardsCombinations := mapIndexToCards(board, boardCombinations)
-	
-	var combinations []cards.Combination
-
-	for _, holeCards := range handCardsCombinations {
-		for _, boardCards := range boardCardsCombinations {
-			usedCards := append(holeCards, boardCards...)
-			combination, err := cards.StrongestCombinationOf(usedCards, cards.DefaultCombinationStrength, false)
-			if err != nil {
-				//This should never happen
-				panic(err)
-			}
-			combinations = append(combinations, *combination)
+	var rank evaluator.LowRank
+	splits := usableSplits(len(hand), len(board), gameConfig)
+	if splits == nil {
+		var err error
+		rank, _, err = evaluator.Low(evaluator.NewCards(append(append([]cards.Card{}, hand...), board...)))
+		if err != nil {
+			//This should never happen
+			panic(err)
 		}
 	}
 
-	sort.Sort(cards.ByCombinationReversed(combinations))
-	return combinations[0]
-}
+	for _, split := range splits {
+		splitRank, _, _, err := evaluator.LowOf(evaluator.NewCards(hand), split[0], evaluator.NewCards(board), split[1])
+		if err != nil {
+			//This should never happen
+			panic(err)
+		}
+		rank = max(rank, splitRank)
+	}
 
-func mapIndexToCards(cs []cards.Card, combinations [][]int) [][]cards.Card {
-	var result [][]cards.Card
-	for _, v := range combinations {
-		parsed := lo.Map(v, func(cardIndex int, _ int) cards.Card {
-			return cs[cardIndex]
-		})
-		result = append(result, parsed)
+	if !rank.IsEightOrBetter() {
+		return 0
 	}
-	return result
+	return rank
 }
 
-func iterate(hands [][]cards.Card, board []cards.Card, gameConfig game.Config) (*HandOddsIteration, error) {
-	deck := cards.NewFullDeck()
-	deck.Shuffle()
+func iterate(hands [][]cards.Card, board []cards.Card, deadCards []cards.Card, gameConfig game.Config, random *rand.Rand) (*HandOddsIteration, error) {
+	deck := gameConfig.NewDeck()
+	deck.ShuffleWith(random)
 
 	err := validateIteration(hands, board, gameConfig)
 	if err != nil {
 		return nil, err
 	}
 	
-	excludedCards := collectExcludedCards(board, hands)
+	excludedCards := collectExcludedCards(board, hands, deadCards)
 	deck = excludeCards(deck, excludedCards)
+	deck, hands = dealUnknownCards(deck, hands, gameConfig.HoleCardsCount)
 	deck, extraCommunityCards := drawCommunityCards(deck, board, gameConfig.CommunityCardsCount)
 
-	
-	combinations := lo.Map(hands, func(cs []cards.Card, _ int) cards.Combination {
-		return strongestHandCombination(cs, board, extraCommunityCards, gameConfig)
-	})
-
-	iteration := HandOddsIteration {
-		Combinations: combinations,
-		Board: append(board, extraCommunityCards...),
-	}
-
+	iteration := newHandOddsIteration(hands, board, extraCommunityCards, gameConfig)
 	return &iteration, nil
 }
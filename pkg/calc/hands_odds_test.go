@@ -1,6 +1,9 @@
 package calc
 
 import (
+	"context"
+	"errors"
+	"time"
 	"testing"
 
 	"github.com/anuarkaliyev23/goker/pkg/cards"
@@ -52,6 +55,7 @@ func generateHandOdds(playersCount int, iterations int) HandOddsResult {
 		Hands: hands,
 		IterationsCount: iterations,
 		GameConfig: game.NewTexasConfig(),
+		KeepIterations: true,
 	}
 
 	odds, err := HandOdds(config)
@@ -161,11 +165,30 @@ func TestHandsOdds(t *testing.T) {
 			Hands: hands, 
 			IterationsCount: iterationCount,
 			GameConfig: game.NewTexasConfig(),
+			KeepIterations: true,
 		}
 
 		odds, err := HandOdds(config)
 		require.NoError(t, err)
 		require.Equal(t, len(odds.Iterations), iterationCount)
+		require.Equal(t, odds.IterationsCount(), iterationCount)
+	})
+
+	t.Run("iterations are not retained by default", func(t *testing.T) {
+		config := HandOddsConfig {
+			Hands: generateHands(2),
+			IterationsCount: 100,
+			GameConfig: game.NewTexasConfig(),
+		}
+
+		odds, err := HandOdds(config)
+		require.NoError(t, err)
+		require.Empty(t, odds.Iterations)
+		require.Equal(t, 100, odds.IterationsCount())
+
+		combinations, err := odds.PlayerCombinations(0)
+		require.Error(t, err)
+		require.Nil(t, combinations)
 	})
 
 	t.Run("negative", func(t *testing.T) {
@@ -198,27 +221,6 @@ func TestHandsOdds(t *testing.T) {
 			require.Error(t, err)
 		})
 
-		// t.Run("inconsistent hand cards count", func(t *testing.T) {
-		// 	hands := generateHands(1)
-		// 	secondsHand := []cards.Card{}
-		//
-		// 	secondsHandSingleCard, err := cards.NewCard(cards.Seven, cards.Spades)
-		// 	require.NoError(t, err)
-		// 	secondsHand = append(secondsHand, *secondsHandSingleCard)
-		//
-		// 	hands = append(hands, secondsHand)
-		//
-		// 	config := HandOddsConfig{
-		// 		Hands: hands,
-		// 		IterationsCount: 1,
-		// 		GameConfig: game.NewTexasConfig(),
-		// 	}
-		//
-		// 	result, err := HandOdds(config)
-		// 	require.Error(t, err)
-		// 	require.Nil(t, result)
-		// })
-
 		t.Run("too many players", func(t *testing.T) {
 			hands := generateHands(23)
 			iterationCount := 1000
@@ -236,6 +238,76 @@ func TestHandsOdds(t *testing.T) {
 	})
 }
 
+func TestHandOdds_UnknownCards(t *testing.T) {
+	seed := int64(5)
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("AsAd vs random hand", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), {}},
+				IterationsCount: 20000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.InDelta(t, 0.852, odds.Equities()[0], 0.015)
+		})
+
+		t.Run("partially known hand", func(t *testing.T) {
+			// Ks? against KhKd on a board, where only the second king could help
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("Ks"), parseCards("KhKd")},
+				Board: parseCards("2c7d9hJs3c"),
+				IterationsCount: 1000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+
+			wins, err := odds.AllPlayerWins()
+			require.NoError(t, err)
+			require.Equal(t, 0, wins[0])
+			require.Greater(t, odds.TieRates()[0], 0.0)
+		})
+
+		t.Run("exhaustive mode falls back to sampling", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), {}},
+				Board: parseCards("2c7d9hJs"),
+				IterationsCount: 100,
+				GameConfig: game.NewTexasConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.False(t, odds.Exhaustive)
+			require.Equal(t, 100, odds.IterationsCount())
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Run("runouts of unknown hands cannot be counted", func(t *testing.T) {
+			_, err := RunoutsCount(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("Kd")},
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.Error(t, err)
+		})
+
+		t.Run("deck is too small for random opponents", func(t *testing.T) {
+			gameConfig := game.NewShortDeckConfig()
+			gameConfig.MaxPlayers = 16
+
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: make([][]cards.Card, 16),
+				IterationsCount: 100,
+				GameConfig: gameConfig,
+			})
+			require.Error(t, err)
+			require.Nil(t, odds)
+		})
+	})
+}
+
 func TestHandOddsIteration_StrongestCombination(t *testing.T) {
 	t.Run("negative", func(t *testing.T) {
 		t.Run("Empty combinations list", func(t *testing.T) {
@@ -319,6 +391,12 @@ func TestHandOddsResult_PlayerWins(t *testing.T) {
 				},
 			}
 
+			iterations := []HandOddsIteration{iteration_1, iteration_2, iteration_3}
+			accumulator := NewHandOddsAccumulator(2)
+			for _, iteration := range iterations {
+				require.NoError(t, accumulator.Add(iteration))
+			}
+
 			handOddsResult := HandOddsResult{
 				Config: HandOddsConfig{
 					IterationsCount: 1000,
@@ -329,7 +407,8 @@ func TestHandOddsResult_PlayerWins(t *testing.T) {
 					Board: []cards.Card{},
 					GameConfig: game.NewTexasConfig(),
 				},
-				Iterations: []HandOddsIteration{iteration_1, iteration_2, iteration_3},
+				Accumulator: accumulator,
+				Iterations: iterations,
 			}
 	
 			firstPlayerWins, err := handOddsResult.PlayerWins(0)
@@ -436,3 +515,226 @@ func TestHandOddsIteration_Winner(t *testing.T) {
 		})
 	})
 }
+
+func TestHandOddsIteration_PotShares(t *testing.T) {
+	t.Run("negative", func(t *testing.T) {
+		iteration := HandOddsIteration{
+			Combinations: []cards.Combination{},
+		}
+		shares, err := iteration.PotShares()
+		require.Error(t, err)
+		require.Nil(t, shares)
+	})
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("single winner", func(t *testing.T) {
+			iteration := HandOddsIteration{
+				Combinations: []cards.Combination{combinationOf("KsTs7h8hKd"), combinationOf("AhKh7h8hKd")},
+			}
+			shares, err := iteration.PotShares()
+			require.NoError(t, err)
+			require.Equal(t, []float64{0, 1}, shares)
+		})
+
+		t.Run("two of three players split", func(t *testing.T) {
+			iteration := HandOddsIteration{
+				Combinations: []cards.Combination{
+					combinationOf("AcAsKdKh8c"),
+					combinationOf("AhAdKsKc8d"),
+					combinationOf("AcAsKdKh7d"),
+				},
+			}
+
+			winners, err := iteration.Winners()
+			require.NoError(t, err)
+			require.Equal(t, []int{0, 1}, winners)
+
+			shares, err := iteration.PotShares()
+			require.NoError(t, err)
+			require.Equal(t, []float64{0.5, 0.5, 0}, shares)
+
+			tie, err := iteration.IsTie()
+			require.NoError(t, err)
+			require.False(t, tie)
+		})
+	})
+}
+
+func TestHandOddsResult_Equities(t *testing.T) {
+	t.Run("partial split counts towards equity", func(t *testing.T) {
+		odds, err := HandOdds(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("Ah9c"), parseCards("Ad9d"), parseCards("KsQh")},
+			Board: parseCards("2c3c4h7s"),
+			GameConfig: game.NewTexasConfig(),
+			Exhaustive: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 42, odds.IterationsCount())
+
+		// KsQh wins only with one of 3 remaining kings or 3 remaining queens
+		equities := odds.Equities()
+		require.InDelta(t, 36.0 / 42 / 2, equities[0], 1e-9)
+		require.InDelta(t, 36.0 / 42 / 2, equities[1], 1e-9)
+		require.InDelta(t, 6.0 / 42, equities[2], 1e-9)
+
+		tieRates := odds.TieRates()
+		require.InDelta(t, 36.0 / 42, tieRates[0], 1e-9)
+		require.Equal(t, 0.0, tieRates[2])
+
+		ties, err := odds.TiePercentage()
+		require.NoError(t, err)
+		require.Equal(t, float32(0), ties)
+	})
+}
+
+func TestHandOddsResult_CombinationStats(t *testing.T) {
+	odds, err := HandOdds(HandOddsConfig{
+		Hands: [][]cards.Card{parseCards("AsKs"), parseCards("QdQc")},
+		Board: parseCards("Qs7s2d5c"),
+		GameConfig: game.NewTexasConfig(),
+		Exhaustive: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 44, odds.IterationsCount())
+
+	t.Run("positive", func(t *testing.T) {
+		stats, err := odds.CombinationStats(0)
+		require.NoError(t, err)
+		require.Equal(t, 9, len(stats))
+
+		// 2s and 5s make a flush, but give QdQc a full house
+		flush := stats[cards.Flush]
+		require.Equal(t, cards.Flush, flush.CombinationType)
+		require.InDelta(t, 9.0 / 44, flush.Frequency, 1e-9)
+		require.InDelta(t, 7.0 / 9, flush.WinRate, 1e-9)
+		require.InDelta(t, 7.0 / 44, flush.Equity, 1e-9)
+
+		villain, err := odds.CombinationStats(1)
+		require.NoError(t, err)
+		require.InDelta(t, 9.0 / 44, villain[cards.FullHouse].Frequency, 1e-9)
+		require.Equal(t, 1.0, villain[cards.FullHouse].WinRate)
+		require.InDelta(t, 1.0 / 44, villain[cards.FourOfAKind].Frequency, 1e-9)
+
+		for player, playerStats := range [][]CombinationStat{stats, villain} {
+			frequencies := lo.SumBy(playerStats, func(stat CombinationStat) float64 { return stat.Frequency })
+			equities := lo.SumBy(playerStats, func(stat CombinationStat) float64 { return stat.Equity })
+			require.InDelta(t, 1.0, frequencies, 1e-9)
+			require.InDelta(t, odds.Equities()[player], equities, 1e-9)
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		stats, err := odds.CombinationStats(2)
+		require.Error(t, err)
+		require.Nil(t, stats)
+	})
+}
+
+func TestHandOddsContext(t *testing.T) {
+	config := HandOddsConfig{
+		Hands: [][]cards.Card{parseCards("AsKsQs9d"), parseCards("8d7d5c4c")},
+		IterationsCount: 1000,
+		GameConfig: game.NewOmahaConfig(),
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("deadline stops precision mode", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100 * time.Millisecond)
+			defer cancel()
+
+			config := config
+			config.Precision = 0.00001
+			result, err := HandOddsContext(ctx, config)
+			require.NoError(t, err)
+			require.True(t, result.Interrupted)
+			require.False(t, result.Precise())
+			require.Greater(t, result.IterationsCount(), 0)
+			require.InDelta(t, 1.0, lo.Sum(result.Equities()), 1e-9)
+		})
+
+		t.Run("completed calculation is not interrupted", func(t *testing.T) {
+			result, err := HandOddsContext(context.Background(), config)
+			require.NoError(t, err)
+			require.False(t, result.Interrupted)
+			require.Equal(t, 1000, result.IterationsCount())
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		for _, exhaustive := range []bool{false, true} {
+			config := config
+			config.Board = parseCards("KhTh2c")
+			config.Exhaustive = exhaustive
+			result, err := HandOddsContext(ctx, config)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, context.Canceled))
+			require.Nil(t, result)
+		}
+	})
+}
+
+func TestHandOdds_BigOmaha(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("exhaustive five card omaha", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsKsQsJd9d"), parseCards("8d7d5c4c3h")},
+				Board: parseCards("KdTd9c2d"),
+				GameConfig: game.NewOmaha5Config(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.True(t, result.Exhaustive)
+			require.Equal(t, 38, result.IterationsCount())
+			require.Equal(t, []int{38, 0}, result.Accumulator.Wins)
+		})
+
+		t.Run("exactly two hole cards are used", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsKsQsJs5s"), parseCards("9d9h4c4d6c")},
+				Board: parseCards("2s3s7h8d9c"),
+				GameConfig: game.NewOmaha5Config(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []int{0, 1}, result.Accumulator.Wins)
+			require.Equal(t, 1, result.Accumulator.CombinationTypes[0][cards.HighCard])
+			require.Equal(t, 1, result.Accumulator.CombinationTypes[1][cards.ThreeOfAKind])
+		})
+
+		t.Run("six card omaha against random hands", func(t *testing.T) {
+			seed := int64(7)
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAdKsKdQhJh"), {}, {}},
+				IterationsCount: 2000,
+				GameConfig: game.NewOmaha6Config(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.InDelta(t, 1.0, lo.Sum(result.Equities()), 1e-9)
+			require.Greater(t, result.Equities()[0], 1.0 / 3)
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Run("hand of invalid size", func(t *testing.T) {
+			_, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsKsQsJd9d2c"), parseCards("8d7d5c4c3h")},
+				IterationsCount: 100,
+				GameConfig: game.NewOmaha5Config(),
+			})
+			require.Error(t, err)
+		})
+
+		t.Run("too many players", func(t *testing.T) {
+			_, err := HandOdds(HandOddsConfig{
+				Hands: make([][]cards.Card, 8),
+				IterationsCount: 100,
+				GameConfig: game.NewOmaha6Config(),
+			})
+			require.Error(t, err)
+		})
+	})
+}
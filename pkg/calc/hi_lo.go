@@ -0,0 +1,74 @@
+package calc
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// SplitOutcome tells how player did in a pot split between high and low hands
+type SplitOutcome int
+
+const (
+	// Player took the whole pot, either both halves or the high one with no qualifying low
+	Scoop SplitOutcome = iota
+	// Player alone took one of the halves and split the other, three quarters of the pot if it was split two ways
+	ThreeQuarters
+	// Player alone took the high half and lost the low one
+	HighOnly
+	// Player alone took the low half and lost the high one
+	LowOnly
+	// Player split the whole pot: both halves, or the high one with no qualifying low
+	Chopped
+	// Player split one of the halves and lost the other
+	Quartered
+)
+
+var splitOutcomesCount = int(Quartered) + 1
+
+func (o SplitOutcome) String() string {
+	switch o {
+	case Scoop:
+		return "Scoop"
+	case ThreeQuarters:
+		return "Three quarters"
+	case HighOnly:
+		return "High only"
+	case LowOnly:
+		return "Low only"
+	case Chopped:
+		return "Chopped"
+	case Quartered:
+		return "Quartered"
+	default:
+		return "Lost"
+	}
+}
+
+type SplitStat struct {
+	Outcome SplitOutcome
+	// Share of iterations player finished with the outcome
+	Frequency float64
+	// Part of player's equity made by the outcome
+	Equity float64
+}
+
+// SplitStats summarises how often player scooped, won or split one of the halves or got quartered in a hi-lo game
+func (r HandOddsResult) SplitStats(index int) ([]SplitStat, error) {
+	if !r.Config.GameConfig.HiLo {
+		return nil, fmt.Errorf("Split outcomes are tracked only for hi-lo games")
+	}
+
+	if index < 0 || index >= r.NumberOfPlayers() {
+		return nil, fmt.Errorf("Player {%d} is out of range, number of players: {%d}", index, r.NumberOfPlayers())
+	}
+
+	iterations := float64(r.IterationsCount())
+	return lo.Times(splitOutcomesCount, func(outcome int) SplitStat {
+		return SplitStat{
+			Outcome: SplitOutcome(outcome),
+			Frequency: float64(r.Accumulator.SplitOutcomes[index][outcome]) / iterations,
+			Equity: r.Accumulator.SplitShares[index][outcome] / iterations,
+		}
+	}), nil
+}
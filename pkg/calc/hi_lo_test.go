@@ -0,0 +1,166 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func splitOutcomeOf(t *testing.T, result *HandOddsResult, player int) SplitOutcome {
+	stats, err := result.SplitStats(player)
+	require.NoError(t, err)
+
+	for _, stat := range stats {
+		if stat.Frequency == 1 {
+			return stat.Outcome
+		}
+	}
+	return -1
+}
+
+func TestHandOdds_HiLo(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("high and low halves", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("KsKd9s9d"), parseCards("As4sJhTh")},
+				Board: parseCards("2c3d7hKcQd"),
+				GameConfig: game.NewOmahaHiLoConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []float64{0.5, 0.5}, result.Equities())
+			require.Equal(t, HighOnly, splitOutcomeOf(t, result, 0))
+			require.Equal(t, LowOnly, splitOutcomeOf(t, result, 1))
+		})
+
+		t.Run("quartered low", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("KsKd9s9d"), parseCards("As4sJhTh"), parseCards("Ah4h8c8h")},
+				Board: parseCards("2c3d7hKcQd"),
+				GameConfig: game.NewOmahaHiLoConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []float64{0.5, 0.25, 0.25}, result.Equities())
+			require.Equal(t, HighOnly, splitOutcomeOf(t, result, 0))
+			require.Equal(t, Quartered, splitOutcomeOf(t, result, 1))
+			require.Equal(t, Quartered, splitOutcomeOf(t, result, 2))
+		})
+
+		t.Run("scoop with both halves", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("As2sKsKd"), parseCards("8s8d9h9d")},
+				Board: parseCards("3d4h7cKcQd"),
+				GameConfig: game.NewOmahaHiLoConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []float64{1, 0}, result.Equities())
+			require.Equal(t, []int{1, 0}, result.Accumulator.Wins)
+			require.Equal(t, Scoop, splitOutcomeOf(t, result, 0))
+			require.Equal(t, SplitOutcome(-1), splitOutcomeOf(t, result, 1))
+		})
+
+		t.Run("high scoops without qualifying low", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd3c4c"), parseCards("KsKd5h6h")},
+				Board: parseCards("KcQd9h8s2c"),
+				GameConfig: game.NewOmahaHiLoConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []float64{0, 1}, result.Equities())
+			require.Equal(t, Scoop, splitOutcomeOf(t, result, 1))
+		})
+
+		t.Run("chopped high without qualifying low", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsKsJd3c"), parseCards("AdKdJc4c")},
+				Board: parseCards("KcQd9h8s2c"),
+				GameConfig: game.NewOmahaHiLoConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []float64{0.5, 0.5}, result.Equities())
+			require.Equal(t, Chopped, splitOutcomeOf(t, result, 0))
+			require.Equal(t, Chopped, splitOutcomeOf(t, result, 1))
+		})
+
+		t.Run("three quarters", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("KsKd4s5s"), parseCards("4h5hJhTh")},
+				Board: parseCards("2c3d7hKcQd"),
+				GameConfig: game.NewOmahaHiLoConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []float64{0.75, 0.25}, result.Equities())
+			require.Equal(t, ThreeQuarters, splitOutcomeOf(t, result, 0))
+			require.Equal(t, Quartered, splitOutcomeOf(t, result, 1))
+		})
+
+		t.Run("chopped both halves", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("Ah4hJsTs"), parseCards("Ad4dJcTc")},
+				Board: parseCards("2c3d7hKcQd"),
+				GameConfig: game.NewOmahaHiLoConfig(),
+				Exhaustive: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []float64{0.5, 0.5}, result.Equities())
+			require.Equal(t, Chopped, splitOutcomeOf(t, result, 0))
+			require.Equal(t, Chopped, splitOutcomeOf(t, result, 1))
+		})
+
+		t.Run("sampled", func(t *testing.T) {
+			seed := int64(11)
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("As2sKdQd"), {}},
+				IterationsCount: 2000,
+				GameConfig: game.NewOmahaHiLoConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.InDelta(t, 1.0, lo.Sum(result.Equities()), 1e-9)
+
+			for player := 0; player < 2; player++ {
+				stats, err := result.SplitStats(player)
+				require.NoError(t, err)
+				require.InDelta(t, result.Equities()[player], lo.SumBy(stats, func(stat SplitStat) float64 {
+					return stat.Equity
+				}), 1e-9)
+			}
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		result := generateHandOdds(2, 10)
+		_, err := result.SplitStats(0)
+		require.Error(t, err)
+
+		hiLo, err := HandOdds(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("KsKd9s9d"), parseCards("As4sJhTh")},
+			Board: parseCards("2c3d7hKcQd"),
+			GameConfig: game.NewOmahaHiLoConfig(),
+			Exhaustive: true,
+		})
+		require.NoError(t, err)
+		_, err = hiLo.SplitStats(2)
+		require.Error(t, err)
+	})
+}
+
+func TestOuts_HiLo(t *testing.T) {
+	t.Run("negative", func(t *testing.T) {
+		_, err := Outs(OutsConfig{
+			Hand: parseCards("As2sKdQd"),
+			Opponents: []cards.Range{{{Cards: parseCards("JsJdTcTh"), Weight: 1}}},
+			Board: parseCards("3c4h9d"),
+			GameConfig: game.NewOmahaHiLoConfig(),
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,245 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/evaluator"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+// Outs are counted starting from the flop
+const FlopCardsCount = 3
+
+type OutsConfig struct {
+	Hand []cards.Card
+	// Ranges of opponents, known hand is a range of a single combo
+	Opponents []cards.Range
+	// Flop or turn
+	Board []cards.Card
+	DeadCards []cards.Card
+	GameConfig game.Config
+}
+
+// knownHands returns hands of opponents whose range is a single combo
+func (c OutsConfig) knownHands() [][]cards.Card {
+	known := lo.Filter(c.Opponents, func(r cards.Range, _ int) bool {
+		return len(r) == 1
+	})
+
+	return lo.Map(known, func(r cards.Range, _ int) []cards.Card {
+		return r[0].Cards
+	})
+}
+
+// Out is a card improving hero from behind to ahead of or level with some of opponents holdings
+type Out struct {
+	Card cards.Card
+	// Type of hero combination after the card
+	CombinationType cards.CombinationType
+	// Weighted share of opponents holdings hero beats after the card
+	Win float64
+	// Weighted share of opponents holdings hero ties with after the card
+	Tie float64
+}
+
+// Clean out puts hero ahead of or level with every opponents holding, tainted one only with some of them
+func (o Out) Clean() bool {
+	return o.Win + o.Tie >= 1 - 1e-9
+}
+
+type OutsResult struct {
+	Config OutsConfig
+	Outs []Out
+	// Number of cards, which hero does not see and next card is dealt from
+	UnseenCardsCount int
+}
+
+func (r OutsResult) CardsToCome() int {
+	return r.Config.GameConfig.CommunityCardsCount - len(r.Config.Board)
+}
+
+func (r OutsResult) CleanOuts() []Out {
+	return lo.Filter(r.Outs, func(out Out, _ int) bool {
+		return out.Clean()
+	})
+}
+
+func (r OutsResult) TaintedOuts() []Out {
+	return lo.Filter(r.Outs, func(out Out, _ int) bool {
+		return !out.Clean()
+	})
+}
+
+// CombinationTypes returns types of combinations outs make, in ascending order
+func (r OutsResult) CombinationTypes() []cards.CombinationType {
+	types := lo.Uniq(lo.Map(r.Outs, func(out Out, _ int) cards.CombinationType {
+		return out.CombinationType
+	}))
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+func (r OutsResult) OutsOfType(combinationType cards.CombinationType) []Out {
+	return lo.Filter(r.Outs, func(out Out, _ int) bool {
+		return out.CombinationType == combinationType
+	})
+}
+
+// NextCardProbability returns probability of one of outsCount outs coming on the next card
+func (r OutsResult) NextCardProbability(outsCount int) float64 {
+	if r.UnseenCardsCount == 0 {
+		return 0
+	}
+	return float64(outsCount) / float64(r.UnseenCardsCount)
+}
+
+// HitProbability returns probability of one of outsCount outs coming by the last community card
+func (r OutsResult) HitProbability(outsCount int) float64 {
+	cardsToCome := r.CardsToCome()
+	if r.UnseenCardsCount < cardsToCome {
+		return 0
+	}
+
+	missed := combin.Binomial(r.UnseenCardsCount - outsCount, cardsToCome)
+	return 1 - float64(missed) / float64(combin.Binomial(r.UnseenCardsCount, cardsToCome))
+}
+
+type outsHolding struct {
+	cards []cards.Card
+	weight float64
+	rank evaluator.Rank
+}
+
+func handRank(hand []cards.Card, board []cards.Card, extraCommunityCards []cards.Card, gameConfig game.Config) evaluator.Rank {
+	rank, _ := rankHand(hand, board, extraCommunityCards, gameConfig)
+	return rank
+}
+
+func validateOuts(config OutsConfig) error {
+	if len(config.Opponents) == 0 {
+		return errors.New("Cannot count outs without opponents")
+	}
+
+	if config.GameConfig.HiLo {
+		return errors.New("Cannot count outs in hi-lo games, improving one half of the pot may not improve the other")
+	}
+
+	if config.GameConfig.DiscardsCount > 0 {
+		return errors.New("Cannot count outs in games with discards, pass kept cards to Texas Hold'em instead")
+	}
+
+	if len(config.Opponents) + 1 > config.GameConfig.MaxPlayers {
+		return fmt.Errorf("Too many players {%d}, should be {%d}", len(config.Opponents) + 1, config.GameConfig.MaxPlayers)
+	}
+
+	if len(config.Hand) != config.GameConfig.HoleCardsCount {
+		return fmt.Errorf("Hand of invalid size {%d}, should be {%d}", len(config.Hand), config.GameConfig.HoleCardsCount)
+	}
+
+	if len(config.Board) < FlopCardsCount || len(config.Board) >= config.GameConfig.CommunityCardsCount {
+		return fmt.Errorf("Cannot count outs for board of {%d} cards, should be from {%d} to {%d}", len(config.Board), FlopCardsCount, config.GameConfig.CommunityCardsCount - 1)
+	}
+
+	err := validateRanges(config.Opponents, config.Board, config.GameConfig)
+	if err != nil {
+		return err
+	}
+
+	hands := append([][]cards.Card{config.Hand}, config.knownHands()...)
+	return validateCards(hands, config.Board, config.DeadCards, config.GameConfig)
+}
+
+// Outs lists every card, which improves hero from behind to ahead or to a tie on the next street
+func Outs(config OutsConfig) (*OutsResult, error) {
+	err := validateOuts(config)
+	if err != nil {
+		return nil, err
+	}
+
+	knownCards := collectExcludedCards(config.Board, [][]cards.Card{config.Hand}, config.DeadCards)
+	holdings := [][]outsHolding{}
+	for player, r := range config.Opponents {
+		playerHoldings := []outsHolding{}
+		for _, hand := range r {
+			if lo.Some(hand.Cards, knownCards) || hand.Weight <= 0 {
+				continue
+			}
+
+			playerHoldings = append(playerHoldings, outsHolding{
+				cards: hand.Cards,
+				weight: hand.Weight,
+				rank: handRank(hand.Cards, config.Board, nil, config.GameConfig),
+			})
+		}
+
+		if len(playerHoldings) == 0 {
+			return nil, fmt.Errorf("Range of player {%d} has no combos left after removing known cards", player)
+		}
+		holdings = append(holdings, playerHoldings)
+	}
+
+	unseen := remainingCards(append([][]cards.Card{config.Hand}, config.knownHands()...), config.Board, config.DeadCards, config.GameConfig)
+	heroRank := handRank(config.Hand, config.Board, nil, config.GameConfig)
+
+	outs := []Out{}
+	for _, card := range unseen {
+		nextCard := []cards.Card{card}
+		heroRankAfter, heroCombination := evaluateHand(config.Hand, config.Board, nextCard, config.GameConfig)
+
+		win, notLose := 1.0, 1.0
+		improved := false
+		possible := true
+
+		for _, playerHoldings := range holdings {
+			total, wins, ties := 0.0, 0.0, 0.0
+
+			for _, holding := range playerHoldings {
+				if lo.Contains(holding.cards, card) {
+					continue
+				}
+
+				rankAfter := handRank(holding.cards, config.Board, nextCard, config.GameConfig)
+				total += holding.weight
+				if heroRankAfter > rankAfter {
+					wins += holding.weight
+				} else if heroRankAfter == rankAfter {
+					ties += holding.weight
+				}
+
+				if heroRank < holding.rank && heroRankAfter >= rankAfter {
+					improved = true
+				}
+			}
+
+			if total == 0 {
+				possible = false
+				break
+			}
+
+			win *= wins / total
+			notLose *= (wins + ties) / total
+		}
+
+		if !possible || !improved || notLose == 0 {
+			continue
+		}
+
+		outs = append(outs, Out{
+			Card: card,
+			CombinationType: heroCombination.Type(),
+			Win: win,
+			Tie: notLose - win,
+		})
+	}
+
+	return &OutsResult{
+		Config: config,
+		Outs: outs,
+		UnseenCardsCount: len(unseen),
+	}, nil
+}
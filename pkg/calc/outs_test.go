@@ -0,0 +1,153 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func outCards(outs []Out) []cards.Card {
+	return lo.Map(outs, func(out Out, _ int) cards.Card {
+		return out.Card
+	})
+}
+
+func TestOuts(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("flush draw vs set", func(t *testing.T) {
+			// 2s gives QdQc a full house, so only 8 spades are left
+			result, err := Outs(OutsConfig{
+				Hand: parseCards("AsKs"),
+				Opponents: []cards.Range{rangeOf(1, "QdQc")},
+				Board: parseCards("Qs7s2d"),
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+
+			require.ElementsMatch(t, parseCards("3s4s5s6s8s9sTsJs"), outCards(result.Outs))
+			require.Equal(t, []cards.CombinationType{cards.Flush}, result.CombinationTypes())
+			require.Equal(t, 8, len(result.CleanOuts()))
+			require.Equal(t, 0, len(result.TaintedOuts()))
+
+			require.Equal(t, 45, result.UnseenCardsCount)
+			require.Equal(t, 2, result.CardsToCome())
+			require.InDelta(t, 8.0 / 45, result.NextCardProbability(8), 1e-9)
+			require.InDelta(t, 1 - 666.0 / 990, result.HitProbability(8), 1e-9)
+		})
+
+		t.Run("outs to a tie on the river", func(t *testing.T) {
+			result, err := Outs(OutsConfig{
+				Hand: parseCards("2c2d"),
+				Opponents: []cards.Range{rangeOf(1, "AsAh")},
+				Board: parseCards("9cTcJdQh"),
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, []cards.CombinationType{cards.ThreeOfAKind, cards.Straight}, result.CombinationTypes())
+			require.ElementsMatch(t, parseCards("2h2s"), outCards(result.OutsOfType(cards.ThreeOfAKind)))
+			lo.ForEach(result.OutsOfType(cards.ThreeOfAKind), func(out Out, _ int) {
+				require.Equal(t, 1.0, out.Win)
+			})
+
+			require.ElementsMatch(t, parseCards("8c8d8h8s"), outCards(result.OutsOfType(cards.Straight)))
+			lo.ForEach(result.OutsOfType(cards.Straight), func(out Out, _ int) {
+				require.Equal(t, 1.0, out.Tie)
+				require.True(t, out.Clean())
+			})
+
+			require.Equal(t, 1, result.CardsToCome())
+			require.InDelta(t, 6.0 / 44, result.HitProbability(6), 1e-9)
+		})
+
+		t.Run("tainted outs vs range", func(t *testing.T) {
+			// set of eights beats AcQc, but not AsAd
+			result, err := Outs(OutsConfig{
+				Hand: parseCards("8h8c"),
+				Opponents: []cards.Range{rangeOf(1, "AsAd", "AcQc")},
+				Board: parseCards("AhKd3s"),
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+
+			require.ElementsMatch(t, parseCards("8d8s"), outCards(result.TaintedOuts()))
+			require.Empty(t, result.CleanOuts())
+			require.InDelta(t, 0.5, result.Outs[0].Win, 1e-9)
+			require.Equal(t, 47, result.UnseenCardsCount)
+		})
+
+		t.Run("no outs when ahead", func(t *testing.T) {
+			result, err := Outs(OutsConfig{
+				Hand: parseCards("QdQc"),
+				Opponents: []cards.Range{rangeOf(1, "AsKs")},
+				Board: parseCards("Qs7s2d"),
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+			require.Empty(t, result.Outs)
+		})
+
+		t.Run("dead cards are not outs", func(t *testing.T) {
+			result, err := Outs(OutsConfig{
+				Hand: parseCards("AsKs"),
+				Opponents: []cards.Range{rangeOf(1, "QdQc")},
+				Board: parseCards("Qs7s2d"),
+				DeadCards: parseCards("3s4s"),
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 6, len(result.Outs))
+			require.Equal(t, 43, result.UnseenCardsCount)
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		invalid := map[string]OutsConfig{
+			"no opponents": {
+				Hand: parseCards("AsKs"),
+				Board: parseCards("Qs7s2d"),
+				GameConfig: game.NewTexasConfig(),
+			},
+			"preflop": {
+				Hand: parseCards("AsKs"),
+				Opponents: []cards.Range{rangeOf(1, "QdQc")},
+				GameConfig: game.NewTexasConfig(),
+			},
+			"river": {
+				Hand: parseCards("AsKs"),
+				Opponents: []cards.Range{rangeOf(1, "QdQc")},
+				Board: parseCards("Qs7s2d3c4c"),
+				GameConfig: game.NewTexasConfig(),
+			},
+			"hand of invalid size": {
+				Hand: parseCards("As"),
+				Opponents: []cards.Range{rangeOf(1, "QdQc")},
+				Board: parseCards("Qs7s2d"),
+				GameConfig: game.NewTexasConfig(),
+			},
+			"opponent hand collides with hero": {
+				Hand: parseCards("AsKs"),
+				Opponents: []cards.Range{rangeOf(1, "AsQc")},
+				Board: parseCards("Qs7s2d"),
+				GameConfig: game.NewTexasConfig(),
+			},
+			"range blocked by board": {
+				Hand: parseCards("AsKs"),
+				Opponents: []cards.Range{rangeOf(1, "QsQc", "7s7c")},
+				Board: parseCards("Qs7s2d"),
+				GameConfig: game.NewTexasConfig(),
+			},
+		}
+
+		for name, config := range invalid {
+			t.Run(name, func(t *testing.T) {
+				result, err := Outs(config)
+				require.Error(t, err)
+				require.Nil(t, result)
+			})
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package calc
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// Iterations are simulated in blocks of this size, every block having its own random source.
+// Since blocks don't depend on the number of workers, seeded results don't depend on it either
+const iterationsBlockSize = 1024
+
+// Workers check for cancellation once per this number of iterations
+const cancellationCheckInterval = 64
+
+func defaultThreads() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// iterationJob simulates a single iteration with given index using block's own random source
+type iterationJob func(random *rand.Rand, index int) (*HandOddsIteration, error)
+
+type partialResult struct {
+	accumulator HandOddsAccumulator
+	iterations []HandOddsIteration
+	err error
+}
+
+// blockSeeds returns seed for every block, derived from config.Seed if it is set
+func blockSeeds(config HandOddsConfig, blocksCount int) []int64 {
+	next := rand.Int63
+	if config.Seed != nil {
+		next = rand.New(rand.NewSource(*config.Seed)).Int63
+	}
+
+	seeds := make([]int64, blocksCount)
+	for i := range seeds {
+		seeds[i] = next()
+	}
+	return seeds
+}
+
+// runBlock simulates iterations with indexes from [from, to), keeping tallies of iterations done before ctx is cancelled
+func runBlock(ctx context.Context, config HandOddsConfig, seed int64, from int, to int, job iterationJob) partialResult {
+	partial := partialResult{
+		accumulator: config.newAccumulator(),
+	}
+	random := rand.New(rand.NewSource(seed))
+
+	for i := from; i < to; i++ {
+		if (i - from) % cancellationCheckInterval == 0 && ctx.Err() != nil {
+			return partial
+		}
+
+		iteration, err := job(random, i)
+		if err != nil {
+			partial.err = err
+			return partial
+		}
+
+		err = partial.accumulator.Add(*iteration)
+		if err != nil {
+			partial.err = err
+			return partial
+		}
+
+		if config.KeepIterations {
+			partial.iterations = append(partial.iterations, *iteration)
+		}
+	}
+	return partial
+}
+
+// runInParallel splits jobsCount iterations between config.Threads workers
+// and merges their tallies in the order of iterations. If ctx is cancelled, the result is marked as interrupted
+// and contains only iterations done so far
+func runInParallel(ctx context.Context, config HandOddsConfig, jobsCount int, job iterationJob) (*HandOddsResult, error) {
+	return runJobsInParallel(ctx, config, 0, jobsCount, job)
+}
+
+// runJobsInParallel simulates iterations with indexes from [from, to), from should be a multiple of iterationsBlockSize.
+// Blocks get the same seeds they would get in a single run, so simulation can be continued without changing results
+func runJobsInParallel(ctx context.Context, config HandOddsConfig, from int, to int, job iterationJob) (*HandOddsResult, error) {
+	threads := config.Threads
+	if threads <= 0 {
+		threads = defaultThreads()
+	}
+
+	firstBlock := from / iterationsBlockSize
+	lastBlock := (to + iterationsBlockSize - 1) / iterationsBlockSize
+	blocksCount := lastBlock - firstBlock
+	seeds := blockSeeds(config, lastBlock)[firstBlock:]
+	partials := make([]partialResult, blocksCount)
+
+	blocks := make(chan int, blocksCount)
+	for block := 0; block < blocksCount; block++ {
+		blocks <- block
+	}
+	close(blocks)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < threads && worker < blocksCount; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for block := range blocks {
+				if ctx.Err() != nil {
+					return
+				}
+
+				blockFrom := from + block * iterationsBlockSize
+				blockTo := min(blockFrom + iterationsBlockSize, to)
+				partials[block] = runBlock(ctx, config, seeds[block], blockFrom, blockTo, job)
+			}
+		}()
+	}
+	wg.Wait()
+
+	result := HandOddsResult{
+		Config: config,
+		Accumulator: config.newAccumulator(),
+	}
+
+	for _, partial := range partials {
+		if partial.err != nil {
+			return nil, partial.err
+		}
+		// Blocks skipped after cancellation
+		if partial.accumulator.IterationsCount == 0 {
+			continue
+		}
+
+		err := result.Accumulator.Merge(partial.accumulator)
+		if err != nil {
+			return nil, err
+		}
+		result.Iterations = append(result.Iterations, partial.iterations...)
+	}
+
+	result.Interrupted = result.IterationsCount() < to - from
+	return &result, nil
+}
@@ -0,0 +1,162 @@
+package calc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/stretchr/testify/require"
+)
+
+// Every iteration carries its job index as the only board card face, so order can be checked
+func indexedJob(_ *rand.Rand, index int) (*HandOddsIteration, error) {
+	return &HandOddsIteration{
+		Combinations: []cards.Combination{combinationOf("AsKsQsJsTs")},
+		Board: []cards.Card{card(cards.Faces[index % len(cards.Faces)], cards.Clubs)},
+	}, nil
+}
+
+func Test_runInParallel(t *testing.T) {
+	config := HandOddsConfig{
+		Hands: [][]cards.Card{parseCards("AsKs")},
+		KeepIterations: true,
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("results are merged in order", func(t *testing.T) {
+			for threads := 1; threads <= 13; threads++ {
+				config.Threads = threads
+				result, err := runInParallel(context.Background(), config, 13, indexedJob)
+				require.NoError(t, err)
+				require.Equal(t, 13, len(result.Iterations))
+				require.Equal(t, 13, result.IterationsCount())
+				require.Equal(t, []int{13}, result.Accumulator.Wins)
+
+				for i, iteration := range result.Iterations {
+					require.Equal(t, cards.Faces[i], iteration.Board[0].Face())
+				}
+			}
+		})
+
+		t.Run("more threads than jobs", func(t *testing.T) {
+			config.Threads = 16
+			result, err := runInParallel(context.Background(), config, 2, indexedJob)
+			require.NoError(t, err)
+			require.Equal(t, 2, result.IterationsCount())
+		})
+	})
+
+	t.Run("cancellation keeps iterations done so far", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		config.Threads = 1
+		result, err := runInParallel(ctx, config, 3000, func(random *rand.Rand, index int) (*HandOddsIteration, error) {
+			if index == 100 {
+				cancel()
+			}
+			return indexedJob(random, index)
+		})
+		require.NoError(t, err)
+		require.True(t, result.Interrupted)
+		require.Equal(t, 2 * cancellationCheckInterval, result.IterationsCount())
+		require.Equal(t, result.IterationsCount(), len(result.Iterations))
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		config.Threads = 4
+		result, err := runInParallel(context.Background(), config, 10, func(random *rand.Rand, index int) (*HandOddsIteration, error) {
+			if index == 0 {
+				return nil, errors.New("failed")
+			}
+			return indexedJob(random, index)
+		})
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestHandOdds_Threads(t *testing.T) {
+	t.Run("exhaustive results do not depend on threads", func(t *testing.T) {
+		config := HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("AsKs"), parseCards("QdQc"), parseCards("7h6h")},
+			Board: parseCards("Qs8h5s2c"),
+			GameConfig: game.NewTexasConfig(),
+			Exhaustive: true,
+		}
+
+		config.Threads = 1
+		single, err := HandOdds(config)
+		require.NoError(t, err)
+
+		config.Threads = 8
+		multiple, err := HandOdds(config)
+		require.NoError(t, err)
+
+		singleWins, err := single.AllPlayerWins()
+		require.NoError(t, err)
+		multipleWins, err := multiple.AllPlayerWins()
+		require.NoError(t, err)
+
+		require.Equal(t, singleWins, multipleWins)
+		require.Equal(t, single.IterationsCount(), multiple.IterationsCount())
+	})
+
+	t.Run("sampling produces requested iterations", func(t *testing.T) {
+		odds, err := HandOdds(HandOddsConfig{
+			Hands: generateHands(3),
+			IterationsCount: 101,
+			GameConfig: game.NewTexasConfig(),
+			Threads: 4,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 101, odds.IterationsCount())
+	})
+}
+
+func TestHandOdds_Seed(t *testing.T) {
+	seed := int64(42)
+	config := HandOddsConfig{
+		Hands: [][]cards.Card{parseCards("AsKs"), parseCards("QdQc"), parseCards("7h6h")},
+		IterationsCount: 3000,
+		GameConfig: game.NewTexasConfig(),
+		Seed: &seed,
+	}
+
+	t.Run("same seed gives same results", func(t *testing.T) {
+		first, err := HandOdds(config)
+		require.NoError(t, err)
+
+		second, err := HandOdds(config)
+		require.NoError(t, err)
+
+		require.Equal(t, first.Accumulator, second.Accumulator)
+	})
+
+	t.Run("results do not depend on threads", func(t *testing.T) {
+		config.Threads = 1
+		single, err := HandOdds(config)
+		require.NoError(t, err)
+
+		config.Threads = 3
+		multiple, err := HandOdds(config)
+		require.NoError(t, err)
+
+		require.Equal(t, single.Accumulator, multiple.Accumulator)
+	})
+
+	t.Run("different seeds give different results", func(t *testing.T) {
+		first, err := HandOdds(config)
+		require.NoError(t, err)
+
+		otherSeed := seed + 1
+		config.Seed = &otherSeed
+		second, err := HandOdds(config)
+		require.NoError(t, err)
+
+		require.NotEqual(t, first.Accumulator, second.Accumulator)
+	})
+}
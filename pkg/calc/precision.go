@@ -0,0 +1,149 @@
+package calc
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/samber/lo"
+)
+
+// z-score of the 95% confidence level
+const ConfidenceZ = 1.96
+
+// Sampling in precision mode stops after this number of iterations, unless HandOddsConfig.MaxIterationsCount is set
+const DefaultMaxIterationsCount = 10000000
+
+// Pot share is within [0, 1], so its variance can't be larger than this
+const maxShareVariance = 0.25
+
+type ConfidenceInterval struct {
+	Low float64
+	High float64
+}
+
+func (c HandOddsConfig) maxIterationsCount() int {
+	if c.MaxIterationsCount <= 0 {
+		return DefaultMaxIterationsCount
+	}
+	return c.MaxIterationsCount
+}
+
+// StandardErrors returns standard error of every player's equity, zero for exhaustive results
+func (r HandOddsResult) StandardErrors() []float64 {
+	n := float64(r.IterationsCount())
+	return lo.Map(r.Accumulator.Shares, func(shares float64, player int) float64 {
+		if r.Exhaustive {
+			return 0
+		}
+		if n < 2 {
+			return math.Sqrt(maxShareVariance / math.Max(n, 1))
+		}
+
+		mean := shares / n
+		variance := (r.Accumulator.SquaredShares[player] / n - mean * mean) * n / (n - 1)
+		return math.Sqrt(math.Max(variance, 0) / n)
+	})
+}
+
+// MarginsOfError returns half-width of 95% confidence interval of every player's equity
+func (r HandOddsResult) MarginsOfError() []float64 {
+	return lo.Map(r.StandardErrors(), func(standardError float64, _ int) float64 {
+		return standardError * ConfidenceZ
+	})
+}
+
+// ConfidenceIntervals returns 95% confidence interval of every player's equity, clamped to [0, 1]
+func (r HandOddsResult) ConfidenceIntervals() []ConfidenceInterval {
+	margins := r.MarginsOfError()
+	return lo.Map(r.Equities(), func(equity float64, player int) ConfidenceInterval {
+		return ConfidenceInterval{
+			Low: math.Max(equity - margins[player], 0),
+			High: math.Min(equity + margins[player], 1),
+		}
+	})
+}
+
+// Precise reports whether margin of error of every player's equity is within Config.Precision
+func (r HandOddsResult) Precise() bool {
+	return lo.EveryBy(r.MarginsOfError(), func(margin float64) bool {
+		return margin <= r.Config.Precision
+	})
+}
+
+func validatePrecision(config HandOddsConfig) error {
+	if config.Precision < 0 || config.Precision >= 1 {
+		return fmt.Errorf("Precision should be within (0, 1), was given {%v}", config.Precision)
+	}
+	return nil
+}
+
+// sample runs config.IterationsCount iterations, or keeps sampling in rounds until config.Precision is reached
+func sample(ctx context.Context, config HandOddsConfig, job iterationJob) (*HandOddsResult, error) {
+	err := validatePrecision(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Precision == 0 {
+		return runInParallel(ctx, config, config.IterationsCount, job)
+	}
+	return sampleToPrecision(ctx, config, job)
+}
+
+func roundToBlocks(iterations int) int {
+	return (iterations + iterationsBlockSize - 1) / iterationsBlockSize * iterationsBlockSize
+}
+
+// sampleToPrecision starts with config.IterationsCount iterations and continues until the result is precise,
+// config.MaxIterationsCount is reached or ctx is done. Rounds consist of whole blocks, so seeded results are the same as of a single run
+func sampleToPrecision(ctx context.Context, config HandOddsConfig, job iterationJob) (*HandOddsResult, error) {
+	result := &HandOddsResult{
+		Config: config,
+		Accumulator: config.newAccumulator(),
+	}
+
+	round := min(roundToBlocks(config.IterationsCount), config.maxIterationsCount())
+	for round > 0 {
+		from := result.IterationsCount()
+		partial, err := runJobsInParallel(ctx, config, from, from + round, job)
+		if err != nil {
+			return nil, err
+		}
+
+		err = result.Accumulator.Merge(partial.Accumulator)
+		if err != nil {
+			return nil, err
+		}
+		result.Iterations = append(result.Iterations, partial.Iterations...)
+
+		if partial.Interrupted {
+			result.Interrupted = true
+			break
+		}
+		if result.Precise() {
+			break
+		}
+		round = nextRoundSize(*result)
+	}
+
+	return result, nil
+}
+
+// nextRoundSize estimates how many more iterations are required to reach the precision,
+// at most doubling the sample every round, so estimates are refined before the cap is hit
+func nextRoundSize(result HandOddsResult) int {
+	config := result.Config
+	done := result.IterationsCount()
+	left := config.maxIterationsCount() - done
+
+	required := float64(done)
+	for _, standardError := range result.StandardErrors() {
+		variance := standardError * standardError * float64(done)
+		required = math.Max(required, variance * math.Pow(ConfidenceZ / config.Precision, 2))
+	}
+
+	round := int(math.Min(required - float64(done), float64(done)))
+	round = min(roundToBlocks(max(round, 1)), left)
+	return max(round, 0)
+}
@@ -0,0 +1,148 @@
+package calc
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandOddsResult_StandardErrors(t *testing.T) {
+	seed := int64(42)
+
+	t.Run("sampled", func(t *testing.T) {
+		result, err := HandOdds(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+			IterationsCount: 1000,
+			GameConfig: game.NewTexasConfig(),
+			Seed: &seed,
+		})
+		require.NoError(t, err)
+
+		equities := result.Equities()
+		standardErrors := result.StandardErrors()
+		intervals := result.ConfidenceIntervals()
+		for player, equity := range equities {
+			// Ties are rare, so the estimate is close to the one of a binomial proportion
+			require.InDelta(t, math.Sqrt(equity * (1 - equity) / 1000), standardErrors[player], 0.001)
+			require.InDelta(t, equity - ConfidenceZ * standardErrors[player], intervals[player].Low, 1e-9)
+			require.InDelta(t, equity + ConfidenceZ * standardErrors[player], intervals[player].High, 1e-9)
+		}
+	})
+
+	t.Run("exhaustive", func(t *testing.T) {
+		config := HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+			Board: parseCards("Kh7c2d5s"),
+			IterationsCount: 1000,
+			GameConfig: game.NewTexasConfig(),
+			Seed: &seed,
+			Exhaustive: true,
+		}
+
+		result, err := HandOdds(config)
+		require.NoError(t, err)
+		require.True(t, result.Exhaustive)
+		require.Equal(t, []float64{0, 0}, result.StandardErrors())
+		require.True(t, result.Precise())
+	})
+}
+
+func TestHandOdds_Precision(t *testing.T) {
+	seed := int64(42)
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("sampling stops when precision is reached", func(t *testing.T) {
+			config := HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				IterationsCount: 1000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+				Precision: 0.01,
+			}
+
+			result, err := HandOdds(config)
+			require.NoError(t, err)
+			require.True(t, result.Precise())
+			require.Greater(t, result.IterationsCount(), 1000)
+			require.Equal(t, 0, result.IterationsCount() % iterationsBlockSize)
+			for _, margin := range result.MarginsOfError() {
+				require.LessOrEqual(t, margin, 0.01)
+			}
+
+			config.Precision = 0
+			config.IterationsCount = result.IterationsCount()
+			single, err := HandOdds(config)
+			require.NoError(t, err)
+			require.Equal(t, single.Accumulator, result.Accumulator)
+		})
+
+		t.Run("works with ranges", func(t *testing.T) {
+			config := HandOddsConfig{
+				Ranges: []cards.Range{rangeOf(1, "AsAd"), rangeOf(1, "KsKd")},
+				IterationsCount: 1000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+				Precision: 0.02,
+			}
+
+			result, err := HandOdds(config)
+			require.NoError(t, err)
+			require.True(t, result.Precise())
+		})
+
+		t.Run("iterations cap", func(t *testing.T) {
+			config := HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				IterationsCount: 1000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+				Precision: 0.0001,
+				MaxIterationsCount: 5000,
+			}
+
+			result, err := HandOdds(config)
+			require.NoError(t, err)
+			require.False(t, result.Precise())
+			require.Equal(t, 5000, result.IterationsCount())
+		})
+
+		t.Run("time limit by context", func(t *testing.T) {
+			config := HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				IterationsCount: 1000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+				Precision: 0.0001,
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50 * time.Millisecond)
+			defer cancel()
+
+			result, err := HandOddsContext(ctx, config)
+			require.NoError(t, err)
+			require.True(t, result.Interrupted)
+			require.False(t, result.Precise())
+			require.Less(t, result.IterationsCount(), config.maxIterationsCount())
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		for _, precision := range []float64{-0.01, 1, 2} {
+			config := HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				IterationsCount: 1000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+				Precision: precision,
+			}
+
+			_, err := HandOdds(config)
+			require.Error(t, err)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package calc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+)
+
+// How many times dealing hands from ranges is retried before giving up on card conflicts
+const maxRangeDealAttempts = 10000
+
+type rangeSampler struct {
+	// combos which do not conflict with known cards, indexed as [player][combo]
+	hands [][][]cards.Card
+	// index of the combo in the original range
+	indexes [][]int
+	// cumulative weights of combos, used to pick weighted combo in O(log n)
+	cumulative [][]float64
+}
+
+// newRangeSampler keeps only combos made of deckCards which are not among knownCards
+func newRangeSampler(ranges []cards.Range, knownCards []cards.Card, deckCards []cards.Card) (*rangeSampler, error) {
+	sampler := rangeSampler{}
+
+	for player, r := range ranges {
+		hands := [][]cards.Card{}
+		indexes := []int{}
+		cumulative := []float64{}
+		total := 0.0
+
+		for index, hand := range r {
+			if lo.Some(hand.Cards, knownCards) || !lo.Every(deckCards, hand.Cards) || hand.Weight <= 0 {
+				continue
+			}
+
+			total += hand.Weight
+			hands = append(hands, hand.Cards)
+			indexes = append(indexes, index)
+			cumulative = append(cumulative, total)
+		}
+
+		if len(hands) == 0 {
+			return nil, fmt.Errorf("Range of player {%d} has no combos left after removing known cards", player)
+		}
+
+		sampler.hands = append(sampler.hands, hands)
+		sampler.indexes = append(sampler.indexes, indexes)
+		sampler.cumulative = append(sampler.cumulative, cumulative)
+	}
+
+	return &sampler, nil
+}
+
+func (s rangeSampler) pick(random *rand.Rand, player int) int {
+	cumulative := s.cumulative[player]
+	target := random.Float64() * cumulative[len(cumulative) - 1]
+	return sort.SearchFloat64s(cumulative, target)
+}
+
+// deal picks a combo for every player, so that no card is dealt twice.
+// Conflicting deals are thrown away entirely, so combos keep their relative weights
+func (s rangeSampler) deal(random *rand.Rand) ([][]cards.Card, []int, error) {
+	for attempt := 0; attempt < maxRangeDealAttempts; attempt++ {
+		hands := make([][]cards.Card, len(s.hands))
+		indexes := make([]int, len(s.hands))
+		dealt := map[cards.Card]bool{}
+		conflict := false
+
+		for player := range s.hands {
+			picked := s.pick(random, player)
+			hand := s.hands[player][picked]
+
+			if lo.SomeBy(hand, func(card cards.Card) bool { return dealt[card] }) {
+				conflict = true
+				break
+			}
+
+			lo.ForEach(hand, func(card cards.Card, _ int) { dealt[card] = true })
+			hands[player] = hand
+			indexes[player] = s.indexes[player][picked]
+		}
+
+		if !conflict {
+			return hands, indexes, nil
+		}
+	}
+
+	return nil, nil, errors.New("Cannot deal non-conflicting hands from given ranges")
+}
+
+func validateRanges(ranges []cards.Range, board []cards.Card, gameConfig game.Config) error {
+	if len(ranges) > gameConfig.MaxPlayers {
+		return fmt.Errorf("Too many players {%d}, should be {%d}", len(ranges), gameConfig.MaxPlayers)
+	}
+
+	if len(board) > gameConfig.CommunityCardsCount {
+		return fmt.Errorf("Cannot construct iteration for board of invalid size, should be less than {%d}", gameConfig.CommunityCardsCount)
+	}
+
+	for player, r := range ranges {
+		if len(r) == 0 {
+			return fmt.Errorf("Range of player {%d} is empty", player)
+		}
+
+		invalid := lo.SomeBy(r, func(hand cards.WeightedHand) bool {
+			return len(hand.Cards) != gameConfig.HoleCardsCount
+		})
+		if invalid {
+			return fmt.Errorf("Range of player {%d} contains hand of invalid size, should be {%d}", player, gameConfig.HoleCardsCount)
+		}
+	}
+
+	return nil
+}
+
+func rangeOdds(ctx context.Context, config HandOddsConfig) (*HandOddsResult, error) {
+	err := validateRanges(config.Ranges, config.Board, config.GameConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateCards([][]cards.Card{}, config.Board, config.DeadCards, config.GameConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	deck := config.GameConfig.NewDeck()
+	knownCards := collectExcludedCards(config.Board, [][]cards.Card{}, config.DeadCards)
+	sampler, err := newRangeSampler(config.Ranges, knownCards, deck.LeftCards())
+	if err != nil {
+		return nil, err
+	}
+
+	return sample(ctx, config, func(random *rand.Rand, _ int) (*HandOddsIteration, error) {
+		hands, indexes, err := sampler.deal(random)
+		if err != nil {
+			return nil, err
+		}
+
+		iteration, err := iterate(hands, config.Board, config.DeadCards, config.GameConfig, random)
+		if err != nil {
+			return nil, err
+		}
+
+		iteration.HandIndexes = indexes
+		return iteration, nil
+	})
+}
@@ -0,0 +1,183 @@
+package calc
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func rangeOf(weight float64, representations ...string) cards.Range {
+	return lo.Map(representations, func(representation string, _ int) cards.WeightedHand {
+		return cards.WeightedHand{Cards: parseCards(representation), Weight: weight}
+	})
+}
+
+func fullDeckCards() []cards.Card {
+	deck := cards.NewFullDeck()
+	return deck.LeftCards()
+}
+
+func Test_rangeSampler(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("combos conflicting with known cards are removed", func(t *testing.T) {
+			sampler, err := newRangeSampler([]cards.Range{rangeOf(1, "AsKs", "AhKh")}, parseCards("Ks7d2c"), fullDeckCards())
+			require.NoError(t, err)
+			require.Equal(t, [][]int{{1}}, sampler.indexes)
+		})
+
+		t.Run("weights are respected", func(t *testing.T) {
+			r := append(rangeOf(1, "AsKs"), rangeOf(0.25, "AhKh")...)
+			sampler, err := newRangeSampler([]cards.Range{r}, []cards.Card{}, fullDeckCards())
+			require.NoError(t, err)
+
+			random := rand.New(rand.NewSource(1))
+			counts := []int{0, 0}
+			for i := 0; i < 10000; i++ {
+				_, indexes, err := sampler.deal(random)
+				require.NoError(t, err)
+				counts[indexes[0]]++
+			}
+
+			require.InDelta(t, 0.8, float64(counts[0]) / 10000, 0.02)
+		})
+
+		t.Run("dealt hands do not conflict", func(t *testing.T) {
+			sampler, err := newRangeSampler([]cards.Range{rangeOf(1, "AsKs", "AhKh"), rangeOf(1, "AsQs", "AdQd")}, []cards.Card{}, fullDeckCards())
+			require.NoError(t, err)
+
+			random := rand.New(rand.NewSource(1))
+			for i := 0; i < 100; i++ {
+				hands, _, err := sampler.deal(random)
+				require.NoError(t, err)
+				require.Equal(t, 4, len(lo.Uniq(lo.Flatten(hands))))
+			}
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Run("every combo is blocked by board", func(t *testing.T) {
+			sampler, err := newRangeSampler([]cards.Range{rangeOf(1, "AsKs")}, parseCards("Ks7d2c"), fullDeckCards())
+			require.Error(t, err)
+			require.Nil(t, sampler)
+		})
+
+		t.Run("ranges always conflict", func(t *testing.T) {
+			sampler, err := newRangeSampler([]cards.Range{rangeOf(1, "AsKs"), rangeOf(1, "AsKs")}, []cards.Card{}, fullDeckCards())
+			require.NoError(t, err)
+
+			hands, indexes, err := sampler.deal(rand.New(rand.NewSource(1)))
+			require.Error(t, err)
+			require.Nil(t, hands)
+			require.Nil(t, indexes)
+		})
+	})
+}
+
+func TestHandOdds_Ranges(t *testing.T) {
+	seed := int64(3)
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("single combo ranges match hands", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Ranges: []cards.Range{rangeOf(1, "AsAd"), rangeOf(1, "KsKd")},
+				IterationsCount: 20000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+
+			equities := odds.Equities()
+			require.InDelta(t, 0.82, equities[0], 0.015)
+			require.InDelta(t, 1.0, equities[0] + equities[1], 1e-9)
+		})
+
+		t.Run("per combo breakdown", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Ranges: []cards.Range{rangeOf(1, "AsKs"), rangeOf(1, "QhQd", "2h3d")},
+				Board: parseCards("Kd7h2c"),
+				IterationsCount: 5000,
+				GameConfig: game.NewTexasConfig(),
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+
+			combos, err := odds.ComboEquities(1)
+			require.NoError(t, err)
+			require.Equal(t, 2, len(combos))
+			require.Equal(t, 5000, combos[0].IterationsCount + combos[1].IterationsCount)
+			require.Less(t, combos[0].Equity, 0.15)
+			require.Greater(t, combos[1].Equity, 0.0)
+
+			// player equity is a weighted mean of combos equities
+			mean := (combos[0].Equity * float64(combos[0].IterationsCount) + combos[1].Equity * float64(combos[1].IterationsCount)) / 5000
+			require.InDelta(t, odds.Equities()[1], mean, 1e-9)
+		})
+
+		t.Run("combos conflicting with board are never dealt", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Ranges: []cards.Range{rangeOf(1, "AsKs", "AhKh"), rangeOf(1, "QhQd")},
+				Board: parseCards("Ks7h2c"),
+				IterationsCount: 100,
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+
+			combos, err := odds.ComboEquities(0)
+			require.NoError(t, err)
+			require.Equal(t, 0, combos[0].IterationsCount)
+			require.Equal(t, 100, combos[1].IterationsCount)
+			require.False(t, math.IsNaN(combos[0].Equity))
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Run("both hands and ranges", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd")},
+				Ranges: []cards.Range{rangeOf(1, "KsKd")},
+				IterationsCount: 100,
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.Error(t, err)
+			require.Nil(t, odds)
+		})
+
+		t.Run("empty range", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Ranges: []cards.Range{rangeOf(1, "AsAd"), {}},
+				IterationsCount: 100,
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.Error(t, err)
+			require.Nil(t, odds)
+		})
+
+		t.Run("hand of invalid size", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Ranges: []cards.Range{rangeOf(1, "AsAd"), rangeOf(1, "KsKdKh")},
+				IterationsCount: 100,
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.Error(t, err)
+			require.Nil(t, odds)
+		})
+
+		t.Run("no range for player", func(t *testing.T) {
+			odds, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+				IterationsCount: 100,
+				GameConfig: game.NewTexasConfig(),
+			})
+			require.NoError(t, err)
+
+			combos, err := odds.ComboEquities(0)
+			require.Error(t, err)
+			require.Nil(t, combos)
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package calc
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+)
+
+type Street int
+
+const (
+	Preflop Street = iota
+	Flop
+	Turn
+	River
+)
+
+// streetBoardSize returns number of community cards known on the street
+func streetBoardSize(street Street) int {
+	if street == Preflop {
+		return 0
+	}
+	return FlopCardsCount + int(street) - int(Flop)
+}
+
+type StreetEquity struct {
+	Street Street
+	Board []cards.Card
+	Result *HandOddsResult
+}
+
+func (r StreetEquity) Equities() []float64 {
+	return r.Result.Equities()
+}
+
+// EquitySwing is the biggest change of player's equity made by cards of a single street
+type EquitySwing struct {
+	Street Street
+	// Cards dealt on the street
+	Cards []cards.Card
+	Player int
+	Change float64
+}
+
+type StreetEquitiesResult struct {
+	Streets []StreetEquity
+	// true if calculation was stopped by context, later streets are missing or partially calculated
+	Interrupted bool
+}
+
+// BiggestSwing returns the biggest change of equity between consecutive streets, false if there are less than two streets
+func (r StreetEquitiesResult) BiggestSwing() (EquitySwing, bool) {
+	swing := EquitySwing{}
+	found := false
+
+	for i := 1; i < len(r.Streets); i++ {
+		previous := r.Streets[i - 1]
+		current := r.Streets[i]
+
+		for player, equity := range current.Equities() {
+			change := equity - previous.Equities()[player]
+			if !found || math.Abs(change) > math.Abs(swing.Change) {
+				found = true
+				swing = EquitySwing{
+					Street: current.Street,
+					Cards: current.Board[len(previous.Board):],
+					Player: player,
+					Change: change,
+				}
+			}
+		}
+	}
+
+	return swing, found
+}
+
+// StreetEquities calculates equities preflop, on the flop, on the turn and on the river of complete config.Board
+func StreetEquities(config HandOddsConfig) (*StreetEquitiesResult, error) {
+	return StreetEquitiesContext(context.Background(), config)
+}
+
+// StreetEquitiesContext stops on cancellation or deadline of ctx and returns streets calculated so far
+func StreetEquitiesContext(ctx context.Context, config HandOddsConfig) (*StreetEquitiesResult, error) {
+	if config.GameConfig.CommunityCardsCount != streetBoardSize(River) {
+		return nil, fmt.Errorf("Cannot split board of {%d} cards into streets", config.GameConfig.CommunityCardsCount)
+	}
+
+	if len(config.Board) != config.GameConfig.CommunityCardsCount {
+		return nil, fmt.Errorf("Cannot calculate equity per street for incomplete board of {%d} cards, should be {%d}", len(config.Board), config.GameConfig.CommunityCardsCount)
+	}
+
+	err := validateCards(config.Hands, config.Board, config.DeadCards, config.GameConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result := StreetEquitiesResult{}
+	for _, street := range []Street{Preflop, Flop, Turn, River} {
+		streetConfig := config
+		streetConfig.Board = config.Board[:streetBoardSize(street)]
+		if street == River {
+			streetConfig.Exhaustive = true
+		}
+
+		if ctx.Err() != nil && len(result.Streets) > 0 {
+			result.Interrupted = true
+			break
+		}
+
+		streetResult, err := HandOddsContext(ctx, streetConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Streets = append(result.Streets, StreetEquity{
+			Street: street,
+			Board: streetConfig.Board,
+			Result: streetResult,
+		})
+		if streetResult.Interrupted {
+			result.Interrupted = true
+			break
+		}
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,92 @@
+package calc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreetEquities(t *testing.T) {
+	seed := int64(1)
+
+	t.Run("positive", func(t *testing.T) {
+		result, err := StreetEquities(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+			Board: parseCards("Kh7c2d5sAh"),
+			IterationsCount: 20000,
+			GameConfig: game.NewTexasConfig(),
+			Exhaustive: true,
+			Seed: &seed,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 4, len(result.Streets))
+
+		preflop := result.Streets[0]
+		require.Equal(t, Preflop, preflop.Street)
+		require.Empty(t, preflop.Board)
+		require.InDelta(t, 0.82, preflop.Equities()[0], 0.015)
+
+		flop := result.Streets[1]
+		require.Equal(t, parseCards("Kh7c2d"), flop.Board)
+		require.True(t, flop.Result.Exhaustive)
+		require.InDelta(t, 85.0 / 990, flop.Equities()[0], 1e-9)
+
+		turn := result.Streets[2]
+		require.InDelta(t, 2.0 / 44, turn.Equities()[0], 1e-9)
+
+		river := result.Streets[3]
+		require.Equal(t, []float64{1, 0}, river.Equities())
+
+		swing, ok := result.BiggestSwing()
+		require.True(t, ok)
+		require.Equal(t, River, swing.Street)
+		require.Equal(t, parseCards("Ah"), swing.Cards)
+		require.Equal(t, 0, swing.Player)
+		require.InDelta(t, 42.0 / 44, swing.Change, 1e-9)
+	})
+
+	t.Run("no swing without two streets", func(t *testing.T) {
+		result := StreetEquitiesResult{Streets: []StreetEquity{{Street: Preflop, Result: &HandOddsResult{}}}, Interrupted: true}
+		_, ok := result.BiggestSwing()
+		require.False(t, ok)
+
+		_, ok = StreetEquitiesResult{}.BiggestSwing()
+		require.False(t, ok)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		invalid := map[string]string{
+			"incomplete board": "Kh7c2d5s",
+			"river card used twice": "Kh7c2d5sAs",
+		}
+
+		for name, board := range invalid {
+			t.Run(name, func(t *testing.T) {
+				result, err := StreetEquities(HandOddsConfig{
+					Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+					Board: parseCards(board),
+					IterationsCount: 100,
+					GameConfig: game.NewTexasConfig(),
+				})
+				require.Error(t, err)
+				require.Nil(t, result)
+			})
+		}
+	})
+}
+
+func TestStreetEquitiesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := StreetEquitiesContext(ctx, HandOddsConfig{
+		Hands: [][]cards.Card{parseCards("AsAd"), parseCards("KsKd")},
+		Board: parseCards("Kh7c2d5sAh"),
+		IterationsCount: 1000,
+		GameConfig: game.NewTexasConfig(),
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+)
+
+func validateUpCards(config HandOddsConfig) error {
+	gameConfig := config.GameConfig
+	if gameConfig.UpCardsCount == 0 {
+		return errors.New("Cannot deal up cards in a game without them")
+	}
+
+	if len(config.UpCards) != len(config.Hands) {
+		return fmt.Errorf("Up cards are given for {%d} players, expected {%d}", len(config.UpCards), len(config.Hands))
+	}
+
+	street := len(config.UpCards[0])
+	if street > gameConfig.UpCardsCount {
+		return fmt.Errorf("Players cannot show {%d} up cards, should be at most {%d}", street, gameConfig.UpCardsCount)
+	}
+
+	for player, upCards := range config.UpCards {
+		if len(upCards) != street {
+			return fmt.Errorf("Player {%d} shows {%d} up cards, but every player is dealt the same number of them, expected {%d}", player, len(upCards), street)
+		}
+	}
+
+	for player, downCards := range config.Hands {
+		if len(downCards) > gameConfig.DownCardsCount() {
+			return fmt.Errorf("Player {%d} has {%d} known down cards, should be at most {%d}", player, len(downCards), gameConfig.DownCardsCount())
+		}
+	}
+	return nil
+}
+
+// joinUpCards adds up cards of every player to their known down cards, so they are never dealt to anyone else
+func joinUpCards(config HandOddsConfig) (HandOddsConfig, error) {
+	if len(config.UpCards) == 0 {
+		return config, nil
+	}
+
+	err := validateUpCards(config)
+	if err != nil {
+		return config, err
+	}
+
+	hands := make([][]cards.Card, len(config.Hands))
+	for player := range config.Hands {
+		hands[player] = append(append([]cards.Card{}, config.Hands[player]...), config.UpCards[player]...)
+	}
+
+	config.Hands = hands
+	config.UpCards = nil
+	return config, nil
+}
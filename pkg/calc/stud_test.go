@@ -0,0 +1,193 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandOdds_Stud(t *testing.T) {
+	seed := int64(5)
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("every card is known", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAdAcKsKd2h3h"), parseCards("QsQdQcJhJd9c8c")},
+				IterationsCount: 4000,
+				GameConfig: game.NewStudConfig(),
+				Exhaustive: true,
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.True(t, result.Exhaustive)
+			require.Equal(t, 1, result.IterationsCount())
+			require.Equal(t, []int{1, 0}, result.Accumulator.Wins)
+			require.Equal(t, 1, result.Accumulator.CombinationTypes[0][cards.FullHouse])
+		})
+
+		t.Run("dead upcards", func(t *testing.T) {
+			live, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAdKc7h"), parseCards("QsQd9c5h")},
+				IterationsCount: 4000,
+				GameConfig: game.NewStudConfig(),
+				Exhaustive: true,
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+
+			dead, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("AsAdKc7h"), parseCards("QsQd9c5h")},
+				DeadCards: parseCards("AcAh"),
+				IterationsCount: 4000,
+				GameConfig: game.NewStudConfig(),
+				Exhaustive: true,
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.False(t, live.Exhaustive)
+			require.InDelta(t, 1.0, lo.Sum(live.Equities()), 1e-9)
+			require.Less(t, dead.Equities()[0], live.Equities()[0])
+		})
+
+		t.Run("hi-lo halves", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("As2d3c4h6sKsKd"), parseCards("QsQdQcJhJd9c8c")},
+				IterationsCount: 4000,
+				GameConfig: game.NewStudHiLoConfig(),
+				Exhaustive: true,
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []float64{0.5, 0.5}, result.Equities())
+			require.Equal(t, LowOnly, splitOutcomeOf(t, result, 0))
+			require.Equal(t, HighOnly, splitOutcomeOf(t, result, 1))
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := HandOdds(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("AsAdKc7h"), parseCards("QsQd9c5h")},
+			Board: parseCards("2c"),
+			IterationsCount: 100,
+			GameConfig: game.NewStudConfig(),
+		})
+		require.Error(t, err)
+
+		_, err = HandOdds(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("AsAdKc7h2c3c4c5c"), {}},
+			IterationsCount: 100,
+			GameConfig: game.NewStudConfig(),
+		})
+		require.Error(t, err)
+
+		_, err = HandOdds(HandOddsConfig{
+			Hands: lo.Times(8, func(_ int) []cards.Card { return []cards.Card{} }),
+			IterationsCount: 100,
+			GameConfig: game.NewStudConfig(),
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestHandOdds_UpCards(t *testing.T) {
+	seed := int64(7)
+
+	t.Run("positive", func(t *testing.T) {
+		withUpCards, err := HandOdds(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("AsAd"), {}},
+			UpCards: [][]cards.Card{parseCards("Kc7h"), parseCards("QsQd")},
+			IterationsCount: 2000,
+			GameConfig: game.NewStudConfig(),
+			Seed: &seed,
+		})
+		require.NoError(t, err)
+		require.Equal(t, [][]cards.Card{parseCards("AsAdKc7h"), parseCards("QsQd")}, withUpCards.Config.Hands)
+
+		joined, err := HandOdds(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("AsAdKc7h"), parseCards("QsQd")},
+			IterationsCount: 2000,
+			GameConfig: game.NewStudConfig(),
+			Seed: &seed,
+		})
+		require.NoError(t, err)
+		require.Equal(t, joined.Equities(), withUpCards.Equities())
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		configs := []HandOddsConfig{
+			// every player is dealt the same number of up cards
+			{Hands: [][]cards.Card{parseCards("AsAd"), {}}, UpCards: [][]cards.Card{parseCards("Kc7h"), parseCards("QsQd9c")}, GameConfig: game.NewStudConfig()},
+			{Hands: [][]cards.Card{parseCards("AsAd"), {}}, UpCards: [][]cards.Card{parseCards("Kc7h2c3c4c"), parseCards("QsQd9c8c7c")}, GameConfig: game.NewStudConfig()},
+			{Hands: [][]cards.Card{parseCards("AsAd2c3c"), {}}, UpCards: [][]cards.Card{parseCards("Kc"), parseCards("Qs")}, GameConfig: game.NewStudConfig()},
+			{Hands: [][]cards.Card{parseCards("AsAd"), {}}, UpCards: [][]cards.Card{parseCards("Kc")}, GameConfig: game.NewStudConfig()},
+			{Hands: [][]cards.Card{parseCards("AsAd"), {}}, UpCards: [][]cards.Card{parseCards("Kc"), parseCards("Qs")}, GameConfig: game.NewTexasConfig()},
+			{Hands: [][]cards.Card{parseCards("AsAd"), {}}, UpCards: [][]cards.Card{parseCards("Kc"), parseCards("As")}, GameConfig: game.NewStudConfig()},
+		}
+
+		for _, config := range configs {
+			config.IterationsCount = 100
+			_, err := HandOdds(config)
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestHandOdds_Razz(t *testing.T) {
+	seed := int64(5)
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("every card is known", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("As2d3c4h6sKsKd"), parseCards("2s3d4c5h7sQdQh")},
+				IterationsCount: 4000,
+				GameConfig: game.NewRazzConfig(),
+				Exhaustive: true,
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []int{1, 0}, result.Accumulator.Wins)
+			require.Equal(t, 1, result.Accumulator.CombinationTypes[0][cards.HighCard])
+		})
+
+		t.Run("made wheel cannot lose", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("As2d3c4h5s"), parseCards("KsKdQcQh")},
+				IterationsCount: 4000,
+				GameConfig: game.NewRazzConfig(),
+				Exhaustive: true,
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []float64{1, 0}, result.Equities())
+
+			stats, err := result.CombinationStats(1)
+			require.NoError(t, err)
+			require.Zero(t, stats[cards.Straight].Frequency)
+			require.Zero(t, stats[cards.Flush].Frequency)
+		})
+
+		t.Run("pairs are bad", func(t *testing.T) {
+			result, err := HandOdds(HandOddsConfig{
+				Hands: [][]cards.Card{parseCards("As2d3c"), parseCards("AdAhKc")},
+				IterationsCount: 4000,
+				GameConfig: game.NewRazzConfig(),
+				Exhaustive: true,
+				Seed: &seed,
+			})
+			require.NoError(t, err)
+			require.Greater(t, result.Equities()[0], 0.8)
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := HandOdds(HandOddsConfig{
+			Hands: [][]cards.Card{parseCards("As2d3c"), parseCards("As4h5h")},
+			IterationsCount: 100,
+			GameConfig: game.NewRazzConfig(),
+		})
+		require.Error(t, err)
+	})
+}
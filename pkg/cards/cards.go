@@ -2,6 +2,7 @@ package cards
 
 import (
 	"fmt"
+
 	"github.com/samber/lo"
 )
 
@@ -48,6 +49,11 @@ func (c Card) Face() Face {
 	return c.face
 }
 
+// index is a position of the card in the full deck, unique for every card
+func (c Card) index() int {
+	return int(c.face) * len(Suits) + int(c.suit)
+}
+
 
 func isValidSuit(s Suit) bool {
 	return lo.Contains(Suits, s)
@@ -71,9 +77,22 @@ func NewCard(face Face, suit Suit) (*Card, error) {
 const FullDeckSize = 52
 const ShortDeckSize = 36
 
+// RandomSource is used by Deck to shuffle cards.
+// *rand.Rand from both math/rand and math/rand/v2 satisfy it
+type RandomSource interface {
+	Shuffle(n int, swap func(i, j int))
+}
+
 type Deck struct {
 	left []Card
 	drawn []Card
+
+	// global random source is used if nil
+	random RandomSource
+}
+
+func (d *Deck) SetRandomSource(random RandomSource) {
+	d.random = random
 }
 
 func (d Deck) allCards() []Card {
@@ -98,6 +117,12 @@ func isValidDeck(d Deck) bool {
 	return d.isUnique() && (d.Size() == FullDeckSize || d.Size() == ShortDeckSize)
 }
 
+func (d Deck) LeftCards() []Card {
+	left := make([]Card, len(d.left))
+	copy(left, d.left)
+	return left
+}
+
 func (d Deck) IsEmpty() bool {
 	return len(d.left) == 0
 }
@@ -108,10 +133,22 @@ func (d *Deck) Collect() {
 }
 
 func (d *Deck) Shuffle() {
+	if d.random != nil {
+		d.ShuffleWith(d.random)
+		return
+	}
+
 	shuffled := lo.Shuffle(d.left)
 	d.left = shuffled
 }
 
+// ShuffleWith shuffles left cards using given random source instead of the deck's one
+func (d *Deck) ShuffleWith(random RandomSource) {
+	random.Shuffle(len(d.left), func(i, j int) {
+		d.left[i], d.left[j] = d.left[j], d.left[i]
+	})
+}
+
 func (d *Deck) CollectAndShuffle() {
 	d.Collect()
 	d.Shuffle()
@@ -157,6 +194,36 @@ func (d *Deck) MoveToDrawn(card Card) error {
 	return nil
 }
 
+// MoveAllToDrawn moves given cards to the drawn ones in a single pass over the left cards,
+// which is much faster than calling MoveToDrawn for every card
+func (d *Deck) MoveAllToDrawn(cards []Card) error {
+	var moving [FullDeckSize]bool
+	for _, card := range cards {
+		if d.CardIsDrawn(card) {
+			return fmt.Errorf("Card {%v} is already drawn", card)
+		}
+		if moving[card.index()] {
+			return fmt.Errorf("Card {%v} is moved more than once", card)
+		}
+		moving[card.index()] = true
+	}
+
+	left := make([]Card, 0, len(d.left))
+	for _, card := range d.left {
+		if !moving[card.index()] {
+			left = append(left, card)
+		}
+	}
+
+	if len(d.left) - len(left) != len(cards) {
+		return fmt.Errorf("Cards {%v} are not present at the left cards", cards)
+	}
+
+	d.drawn = append(d.drawn, cards...)
+	d.left = left
+	return nil
+}
+
 func NewDeckWithoutValidation(cards []Card) Deck {
 	d := Deck{
 		left: cards,
@@ -173,25 +240,25 @@ func NewDeck(cards []Card) (*Deck, error) {
 	return &d, nil
 }
 
-func NewFullDeck() Deck {
+func newDeckCards(faces []Face) []Card {
 	cards := []Card{}
-	for _, face := range Faces {
+	for _, face := range faces {
 		for _, suit := range Suits {
 			c, _ := NewCard(face, suit)
 			cards = append(cards, *c)
 		}
 	}
-	return NewDeckWithoutValidation(cards)
+	return cards
+}
+
+// Decks are constructed on every simulated iteration, so their cards are prepared once and copied
+var fullDeckCards = newDeckCards(Faces)
+var shortDeckCards = newDeckCards([]Face{Six, Seven, Eight, Nine, Ten, Jack, Queen, King, Ace})
+
+func NewFullDeck() Deck {
+	return NewDeckWithoutValidation(append([]Card{}, fullDeckCards...))
 }
 
 func NewShortDeck() Deck {
-	cards := []Card{}
-	validFaces := []Face{Six, Seven, Eight, Nine, Ten, Jack, Queen, King, Ace}
-	for _, face := range validFaces{
-		for _, suit := range Suits {
-			c, _ := NewCard(face, suit)
-			cards = append(cards, *c)
-		}
-	}
-	return NewDeckWithoutValidation(cards)
+	return NewDeckWithoutValidation(append([]Card{}, shortDeckCards...))
 }
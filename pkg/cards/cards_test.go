@@ -1,6 +1,7 @@
 package cards
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/samber/lo"
@@ -173,3 +174,80 @@ func TestDeck_MoveToDraw(t *testing.T) {
 		})
 	})
 }
+
+func TestDeck_MoveAllToDrawn(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		moved := []Card{{face: Queen, suit: Spades}, {face: Two, suit: Clubs}, {face: Ace, suit: Hearts}}
+		deck := NewFullDeck()
+		deck.ShuffleWith(rand.New(rand.NewSource(42)))
+
+		expected := NewFullDeck()
+		expected.ShuffleWith(rand.New(rand.NewSource(42)))
+		for _, card := range moved {
+			require.NoError(t, expected.MoveToDrawn(card))
+		}
+
+		err := deck.MoveAllToDrawn(moved)
+		require.NoError(t, err)
+		require.Equal(t, expected.left, deck.left)
+		require.Equal(t, moved, deck.drawn)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Run("card already drawn", func(t *testing.T) {
+			deck := NewFullDeck()
+			drawnCard, err := deck.Draw()
+			require.NoError(t, err)
+
+			err = deck.MoveAllToDrawn([]Card{*drawnCard})
+			require.Error(t, err)
+		})
+
+		t.Run("card is not in left", func(t *testing.T) {
+			deck := NewShortDeck()
+			err := deck.MoveAllToDrawn([]Card{{face: Two, suit: Clubs}})
+			require.Error(t, err)
+			require.Equal(t, ShortDeckSize, len(deck.left))
+		})
+
+		t.Run("card is moved twice", func(t *testing.T) {
+			deck := NewFullDeck()
+			err := deck.MoveAllToDrawn([]Card{{face: Two, suit: Clubs}, {face: Two, suit: Clubs}})
+			require.Error(t, err)
+		})
+	})
+}
+
+func TestDeck_ShuffleWith(t *testing.T) {
+	t.Run("same source produces same order", func(t *testing.T) {
+		first := NewFullDeck()
+		first.ShuffleWith(rand.New(rand.NewSource(42)))
+
+		second := NewFullDeck()
+		second.ShuffleWith(rand.New(rand.NewSource(42)))
+
+		require.Equal(t, first.left, second.left)
+		require.ElementsMatch(t, allCards(), first.left)
+	})
+}
+
+func TestDeck_Shuffle(t *testing.T) {
+	t.Run("injected random source makes shuffle repeatable", func(t *testing.T) {
+		first := NewFullDeck()
+		first.SetRandomSource(rand.New(rand.NewSource(7)))
+		first.Shuffle()
+
+		second := NewFullDeck()
+		second.SetRandomSource(rand.New(rand.NewSource(7)))
+		second.Shuffle()
+
+		require.Equal(t, first.left, second.left)
+		require.NotEqual(t, NewFullDeck().left, first.left)
+	})
+
+	t.Run("global random source", func(t *testing.T) {
+		deck := NewFullDeck()
+		deck.Shuffle()
+		require.ElementsMatch(t, allCards(), deck.left)
+	})
+}
@@ -2203,3 +2203,26 @@ func TestShortDeckCombinations(t *testing.T) {
 			require.True(t, less.Less(more))
 	})
 }
+
+func TestShortDeckStraight(t *testing.T) {
+	aceToNine := []Card{
+		{face: Ace, suit: Diamonds},
+		{face: Six, suit: Hearts},
+		{face: Seven, suit: Diamonds},
+		{face: Eight, suit: Clubs},
+		{face: Nine, suit: Spades},
+	}
+
+	t.Run("A6789 is a straight in short-deck", func(t *testing.T) {
+		combination, err := NewShortDeckCombination(append([]Card{}, aceToNine...))
+		require.NoError(t, err)
+		require.Equal(t, Straight, combination.Type())
+		require.Equal(t, Nine, combination.MainCard())
+	})
+
+	t.Run("A6789 is not a straight in full deck", func(t *testing.T) {
+		combination, err := NewDefaultCombination(append([]Card{}, aceToNine...))
+		require.NoError(t, err)
+		require.Equal(t, HighCard, combination.Type())
+	})
+}
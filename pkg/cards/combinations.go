@@ -12,7 +12,8 @@ import (
 
 type CombinationType int
 
-const validCardsLength = 5
+// Number of cards every combination is made of
+const CombinationLength = 5
 const validStraightSum = 10
 
 const validCombinatoricsLength = 7
@@ -50,6 +51,7 @@ type Combination struct {
 	cards []Card
 	combinationStrengths []CombinationType
 	shortDeck bool
+	ranking Ranking
 }
 
 func (r Combination) AllCards() []Card {
@@ -114,6 +116,9 @@ func (r Combination) MainCard() Face {
 			if lo.Contains(r.toFaces(), Ace) && lo.Contains(r.toFaces(), Two) {
 				return Five
 			}
+			if r.shortDeck && lo.Contains(r.toFaces(), Ace) && lo.Contains(r.toFaces(), Six) {
+				return Nine
+			}
 		}
 		return r.HighestCardFace()
 	}
@@ -177,21 +182,32 @@ func (r Combination) SecondaryCard() *Face {
 }
 
 func (r Combination) isFlush() bool {
+	if r.ranking == AceToFiveRanking {
+		return false
+	}
+
 	suits := lo.Map(r.cards, func(card Card, index int) Suit {
 		return card.Suit()
 	})
 
 	suitCount := lo.Count(suits, suits[0])
-	return suitCount == validCardsLength
+	return suitCount == CombinationLength
 }
 
 func (r Combination) isStraight() bool {
+	if r.ranking == AceToFiveRanking {
+		return false
+	}
+
 	faces := lo.Map(r.cards, func(card Card, index int) Face {
 		return card.Face()
 	})
 
 	containsAce := lo.Contains(faces, Ace)
-	if containsAce {
+	if containsAce && r.ranking == DeuceToSevenRanking {
+		// aces are always high in deuce-to-seven, so A-2-3-4-5 is not a straight
+		return lo.Every(faces, []Face{Ten, Jack, Queen, King})
+	} else if containsAce {
 		return isStraightWithAce(r.cards, r.shortDeck)
 	} else {
 		return isStraightNoAce(r.cards)
@@ -220,20 +236,18 @@ func isStraightWithAce(cards []Card, shortDeck bool) bool {
 		containsTen := lo.Contains(faces, Ten)
 
 		return containsQueen && containsJack && containsTen
-	} else if !shortDeck {
-		if containsTwo {
-			containsThree := lo.Contains(faces, Three)
-			containsFour := lo.Contains(faces, Four)
-			containsFive := lo.Contains(faces, Five)
-			return containsThree && containsFour && containsFive
-		} else {
-			if containsSix {
-				containsSeven := lo.Contains(faces, Seven)
-				containsEight := lo.Contains(faces, Eight)
-				containsNine := lo.Contains(faces, Nine)
-				return containsSeven && containsEight && containsNine
-			}
+	} else if shortDeck {
+		if containsSix {
+			containsSeven := lo.Contains(faces, Seven)
+			containsEight := lo.Contains(faces, Eight)
+			containsNine := lo.Contains(faces, Nine)
+			return containsSeven && containsEight && containsNine
 		}
+	} else if containsTwo {
+		containsThree := lo.Contains(faces, Three)
+		containsFour := lo.Contains(faces, Four)
+		containsFive := lo.Contains(faces, Five)
+		return containsThree && containsFour && containsFive
 	}
 	return false
 }
@@ -324,7 +338,23 @@ func (r Combination) lessByType(first CombinationType, second CombinationType) b
 	return firstIndex < secondIndex
 }
 
+// Ranking returns how combination is compared with others
+func (r Combination) Ranking() Ranking {
+	return r.ranking
+}
+
 func (r Combination) Less(other Combination) bool {
+	switch r.ranking {
+	case DeuceToSevenRanking:
+		return other.lessHigh(r)
+	case AceToFiveRanking:
+		return lessAceToFive(r, other)
+	default:
+		return r.lessHigh(other)
+	}
+}
+
+func (r Combination) lessHigh(other Combination) bool {
 	combinations := []Combination{r, other}
 
 	if r.lessByType(r.Type(), other.Type()) {
@@ -406,25 +436,33 @@ func NewShortDeckCombination(cards []Card) (*Combination, error) {
 }
 
 func NewCombination(cards []Card, combinationStrengths []CombinationType, shortDeck bool) (*Combination, error) {
+	return newRankedCombination(cards, combinationStrengths, shortDeck, HighRanking)
+}
+
+func newRankedCombination(cards []Card, combinationStrengths []CombinationType, shortDeck bool, ranking Ranking) (*Combination, error) {
 	sort.Sort(ByFace(cards))
-	if len(cards) == validCardsLength {
+	if len(cards) == CombinationLength {
 		uniques := lo.Uniq(cards)
-		if len(uniques) != validCardsLength {
+		if len(uniques) != CombinationLength {
 			return nil, errors.New("cannot construct combination with not unique cards")
 		}
-		return &Combination{cards: cards, combinationStrengths: combinationStrengths, shortDeck: shortDeck}, nil
+		return &Combination{cards: cards, combinationStrengths: combinationStrengths, shortDeck: shortDeck, ranking: ranking}, nil
 	} else {
-		return nil, fmt.Errorf("cannot construct a combination you must pass slice of size: %d", validCardsLength)
+		return nil, fmt.Errorf("cannot construct a combination you must pass slice of size: %d", CombinationLength)
 	}
 }
 
 
 func CombinationsOf(cards[] Card, combinationStrengths []CombinationType, shortDeck bool) ([]Combination, error) {
-	if len(cards) < validCardsLength {
+	return rankedCombinationsOf(cards, combinationStrengths, shortDeck, HighRanking)
+}
+
+func rankedCombinationsOf(cards[] Card, combinationStrengths []CombinationType, shortDeck bool, ranking Ranking) ([]Combination, error) {
+	if len(cards) < CombinationLength {
 		return nil, fmt.Errorf("Cannot construct combinations from {%d} cards, must be more than 5", len(cards))
 	}
 
-	combinatoricsCombinations := combin.Combinations(len(cards), validCardsLength)
+	combinatoricsCombinations := combin.Combinations(len(cards), CombinationLength)
 	for i := range combinatoricsCombinations {
 		sort.Ints(combinatoricsCombinations[i])
 	}
@@ -436,7 +474,7 @@ func CombinationsOf(cards[] Card, combinationStrengths []CombinationType, shortD
 			return cards[value]
 		})
 		
-		combination, err := NewCombination(toCards, combinationStrengths, shortDeck)
+		combination, err := newRankedCombination(toCards, combinationStrengths, shortDeck, ranking)
 
 		if err != nil {
 			return nil, err
@@ -0,0 +1,83 @@
+package cards
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// Ranking tells which of two combinations is stronger
+type Ranking int
+
+const (
+	// The best high hand wins, combination types are ordered by combination strengths
+	HighRanking Ranking = iota
+	// Deuce-to-seven lowball: the worst high hand wins, aces are always high and straights and flushes count against the hand
+	DeuceToSevenRanking
+	// Ace-to-five lowball: the lowest hand wins, aces are low and straights and flushes are ignored
+	AceToFiveRanking
+)
+
+// Combination types of deuce-to-seven lowball from the weakest to the strongest
+var DeuceToSevenCombinationStrength = []CombinationType{StraightFlush, FourOfAKind, FullHouse, Flush, Straight, ThreeOfAKind, TwoPair, Pair, HighCard}
+
+// Combination types of ace-to-five lowball from the weakest to the strongest, straights and flushes don't exist there
+var AceToFiveCombinationStrength = []CombinationType{FourOfAKind, FullHouse, ThreeOfAKind, TwoPair, Pair, HighCard}
+
+// NewLowballCombination constructs combination compared by one of lowball rankings, stronger combination is the better low
+func NewLowballCombination(cards []Card, ranking Ranking) (*Combination, error) {
+	return newRankedCombination(cards, DefaultCombinationStrength, false, ranking)
+}
+
+// LowballCombinationsOf returns every 5-card combination of cards, from the strongest under the ranking
+func LowballCombinationsOf(cards []Card, ranking Ranking) ([]Combination, error) {
+	return rankedCombinationsOf(cards, DefaultCombinationStrength, false, ranking)
+}
+
+func StrongestLowballCombinationOf(cards []Card, ranking Ranking) (*Combination, error) {
+	combinations, err := LowballCombinationsOf(cards, ranking)
+	if err != nil {
+		return nil, err
+	}
+
+	return &combinations[0], nil
+}
+
+// aceToFiveFace returns value of the face with ace as the lowest
+func aceToFiveFace(face Face) int {
+	if face == Ace {
+		return -1
+	}
+	return int(face)
+}
+
+// aceToFiveFaces returns faces with ace as the lowest, ordered by count first and from the highest second
+func (r Combination) aceToFiveFaces() []int {
+	counts := lo.CountValues(r.toFaces())
+	faces := lo.Keys(counts)
+	sort.Slice(faces, func(i, j int) bool {
+		if counts[faces[i]] != counts[faces[j]] {
+			return counts[faces[i]] > counts[faces[j]]
+		}
+		return aceToFiveFace(faces[i]) > aceToFiveFace(faces[j])
+	})
+
+	return lo.Map(faces, func(face Face, _ int) int {
+		return aceToFiveFace(face)
+	})
+}
+
+// lessAceToFive reports whether first is the worse ace-to-five low: paired hands lose to unpaired ones, then the highest face loses
+func lessAceToFive(first Combination, second Combination) bool {
+	if first.Type() != second.Type() {
+		return first.Type() > second.Type()
+	}
+
+	secondFaces := second.aceToFiveFaces()
+	for i, face := range first.aceToFiveFaces() {
+		if face != secondFaces[i] {
+			return face > secondFaces[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package cards
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+// lowballCombination builds combination of given faces, all suited if suited is set and offsuit otherwise
+func lowballCombination(t *testing.T, ranking Ranking, suited bool, faces ...Face) Combination {
+	hand := lo.Map(faces, func(face Face, i int) Card {
+		if suited {
+			return Card{face: face, suit: Hearts}
+		}
+		return Card{face: face, suit: Suits[i % len(Suits)]}
+	})
+
+	combination, err := NewLowballCombination(hand, ranking)
+	require.NoError(t, err)
+	return *combination
+}
+
+func TestCombination_Less_DeuceToSeven(t *testing.T) {
+	low := func(t *testing.T, suited bool, faces ...Face) Combination {
+		return lowballCombination(t, DeuceToSevenRanking, suited, faces...)
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("seven-five is the nuts", func(t *testing.T) {
+			nuts := low(t, false, Seven, Five, Four, Three, Two)
+			require.True(t, low(t, false, Eight, Six, Four, Three, Two).Less(nuts))
+			require.True(t, nuts.More(low(t, false, Seven, Six, Four, Three, Two)))
+		})
+
+		t.Run("straights and flushes count against", func(t *testing.T) {
+			eight := low(t, false, Eight, Six, Four, Three, Two)
+			require.Equal(t, Straight, low(t, false, Seven, Six, Five, Four, Three).Type())
+			require.True(t, low(t, false, Seven, Six, Five, Four, Three).Less(eight))
+			require.Equal(t, Flush, low(t, true, Seven, Five, Four, Three, Two).Type())
+			require.True(t, low(t, true, Seven, Five, Four, Three, Two).Less(low(t, false, King, Queen, Jack, Nine, Eight)))
+		})
+
+		t.Run("aces are high", func(t *testing.T) {
+			wheel := low(t, false, Ace, Five, Four, Three, Two)
+			require.Equal(t, HighCard, wheel.Type())
+			require.Equal(t, Ace, wheel.MainCard())
+			require.True(t, wheel.Less(low(t, false, King, Queen, Jack, Nine, Eight)))
+			require.True(t, low(t, false, Two, Two, Five, Four, Three).Less(wheel))
+		})
+
+		t.Run("equal lows tie", func(t *testing.T) {
+			require.True(t, low(t, false, Seven, Five, Four, Three, Two).Tie(low(t, false, Two, Three, Four, Five, Seven)))
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		require.False(t, low(t, false, Seven, Five, Four, Three, Two).Less(low(t, false, Eight, Six, Four, Three, Two)))
+		require.Equal(t, Straight, low(t, false, Ace, King, Queen, Jack, Ten).Type())
+	})
+}
+
+func TestCombination_Less_AceToFive(t *testing.T) {
+	low := func(t *testing.T, suited bool, faces ...Face) Combination {
+		return lowballCombination(t, AceToFiveRanking, suited, faces...)
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("wheel is the nuts", func(t *testing.T) {
+			wheel := low(t, true, Ace, Two, Three, Four, Five)
+			require.Equal(t, HighCard, wheel.Type())
+			require.True(t, low(t, false, Six, Four, Three, Two, Ace).Less(wheel))
+		})
+
+		t.Run("highest face decides first", func(t *testing.T) {
+			require.True(t, low(t, false, Eight, Four, Three, Two, Ace).Less(low(t, false, Seven, Six, Five, Four, Three)))
+			require.True(t, low(t, false, Seven, Six, Three, Two, Ace).Less(low(t, false, Seven, Five, Four, Three, Two)))
+		})
+
+		t.Run("pairs lose to unpaired hands", func(t *testing.T) {
+			require.Equal(t, Pair, low(t, false, Ace, Ace, Two, Three, Four).Type())
+			require.True(t, low(t, false, Ace, Ace, Two, Three, Four).Less(low(t, false, King, Queen, Jack, Ten, Nine)))
+			require.True(t, low(t, false, Two, Two, Five, Four, Three).Less(low(t, false, Ace, Ace, Five, Four, Three)))
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		require.False(t, low(t, false, Six, Four, Three, Two, Ace).Less(low(t, false, Six, Five, Three, Two, Ace)))
+		require.False(t, low(t, false, Six, Four, Three, Two, Ace).Less(low(t, true, Six, Four, Three, Two, Ace)))
+	})
+}
+
+func TestStrongestLowballCombinationOf(t *testing.T) {
+	hand := []Card{
+		{face: Ace, suit: Hearts},
+		{face: Two, suit: Hearts},
+		{face: Three, suit: Hearts},
+		{face: Four, suit: Hearts},
+		{face: Five, suit: Hearts},
+		{face: Seven, suit: Clubs},
+		{face: King, suit: Spades},
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("ace-to-five", func(t *testing.T) {
+			strongest, err := StrongestLowballCombinationOf(append([]Card{}, hand...), AceToFiveRanking)
+			require.NoError(t, err)
+			require.Equal(t, AceToFiveRanking, strongest.Ranking())
+			require.ElementsMatch(t, []Face{Ace, Two, Three, Four, Five}, strongest.toFaces())
+		})
+
+		t.Run("deuce-to-seven", func(t *testing.T) {
+			strongest, err := StrongestLowballCombinationOf(append([]Card{}, hand...), DeuceToSevenRanking)
+			require.NoError(t, err)
+			require.Equal(t, HighCard, strongest.Type())
+			require.ElementsMatch(t, []Face{Seven, Five, Four, Three, Two}, strongest.toFaces())
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		strongest, err := StrongestLowballCombinationOf(hand[:4], AceToFiveRanking)
+		require.Error(t, err)
+		require.Nil(t, strongest)
+	})
+}
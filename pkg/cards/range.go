@@ -0,0 +1,26 @@
+package cards
+
+// WeightedHand is a concrete hole cards combo along with its relative frequency in a range
+type WeightedHand struct {
+	Cards []Card
+	Weight float64
+}
+
+// Range is a set of hole cards combos a player may hold
+type Range []WeightedHand
+
+func (r Range) Hands() [][]Card {
+	hands := make([][]Card, len(r))
+	for i, hand := range r {
+		hands[i] = hand.Cards
+	}
+	return hands
+}
+
+func (r Range) TotalWeight() float64 {
+	total := 0.0
+	for _, hand := range r {
+		total += hand.Weight
+	}
+	return total
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/anuarkaliyev23/goker/pkg/calc"
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	utils "github.com/anuarkaliyev23/goker/pkg/cmd/utils"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/fatih/color"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+const (
+	TripleDrawFlagName = "triple-draw"
+	FiveCardDrawFlagName = "five-card-draw"
+)
+
+// Marks player, who throws every card on the next draw
+const NothingKept = "-"
+
+var drawsFlag []int
+var tripleDrawFlag bool
+var fiveCardDrawFlag bool
+
+var drawEquityCmd = &cobra.Command{
+	Use: "draw-equity",
+	Short: "compare equity of draw game hands, given cards every player keeps and draws they have left",
+	RunE: func(c *cobra.Command, args []string) error {
+		config, err := drawEquityConfig(handsFlag, drawsFlag, iterationsFlag, selectedDrawGameConfig())
+		if err != nil {
+			return err
+		}
+		err = applySimulationFlags(c, config)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := simulationContext(c)
+		defer cancel()
+
+		labels := lo.Map(handsFlag, func(hand string, player int) string {
+			return fmt.Sprintf("%s, draws: %d", hand, config.Draws[player])
+		})
+
+		err, executionDuration := utils.MeasureTime(func() error {
+			result, err := calc.HandOddsContext(ctx, *config)
+			if err != nil {
+				return err
+			}
+			return printHandOdds(result, labels)
+		})
+		if err != nil {
+			return err
+		}
+		color.White(fmt.Sprintf("%d ms\n", executionDuration))
+		return nil
+	},
+}
+
+func selectedDrawGameConfig() game.Config {
+	if fiveCardDrawFlag {
+		return game.NewFiveCardDrawConfig()
+	}
+	return game.NewTripleDrawConfig()
+}
+
+// drawEquityConfig constructs config of a draw game, where a single number of draws is shared by every player
+func drawEquityConfig(handsRepresentation []string, draws []int, iterations int, gameConfig game.Config) (*calc.HandOddsConfig, error) {
+	hands := [][]cards.Card{}
+	for _, representation := range handsRepresentation {
+		if representation == NothingKept {
+			hands = append(hands, []cards.Card{})
+			continue
+		}
+
+		hand, err := utils.ParseCards(representation)
+		if err != nil {
+			return nil, err
+		}
+		hands = append(hands, hand)
+	}
+
+	if len(draws) == 1 {
+		draws = lo.Times(len(hands), func(_ int) int { return draws[0] })
+	}
+
+	if len(draws) != len(hands) {
+		return nil, fmt.Errorf("Draws are given for {%d} players, expected {%d} or a single number for everyone", len(draws), len(hands))
+	}
+
+	return &calc.HandOddsConfig{
+		Hands: hands,
+		Draws: draws,
+		IterationsCount: iterations,
+		GameConfig: gameConfig,
+	}, nil
+}
+
+func init() {
+	drawEquityCmd.Flags().StringSliceVar(&handsFlag, "hands", nil, fmt.Sprintf("cards every player keeps on the next draw, \"%s\" if player throws them all", NothingKept))
+	drawEquityCmd.Flags().IntSliceVar(&drawsFlag, "draws", []int{1}, "draws every player has left, a single number is shared by everyone")
+	addSimulationFlags(drawEquityCmd)
+	drawEquityCmd.MarkFlagRequired("hands")
+
+	drawEquityCmd.Flags().BoolVar(&tripleDrawFlag, TripleDrawFlagName, false, "flag to indicate 2-7 Triple Draw")
+	drawEquityCmd.Flags().BoolVar(&fiveCardDrawFlag, FiveCardDrawFlagName, false, "flag to indicate Five Card Draw")
+	drawEquityCmd.MarkFlagsOneRequired(TripleDrawFlagName, FiveCardDrawFlagName)
+	drawEquityCmd.MarkFlagsMutuallyExclusive(TripleDrawFlagName, FiveCardDrawFlagName)
+
+	rootCmd.AddCommand(drawEquityCmd)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_drawEquityConfig(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		config, err := drawEquityConfig([]string{"7s5d3c2h", NothingKept}, []int{2}, 10, game.NewTripleDrawConfig())
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 2}, config.Draws)
+		require.Equal(t, 4, len(config.Hands[0]))
+		require.Empty(t, config.Hands[1])
+
+		config, err = drawEquityConfig([]string{"7s5d3c2h", "9s6h4h3s2c"}, []int{3, 0}, 10, game.NewTripleDrawConfig())
+		require.NoError(t, err)
+		require.Equal(t, []int{3, 0}, config.Draws)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		for _, draws := range [][]int{{}, {1, 2, 3}} {
+			config, err := drawEquityConfig([]string{"7s5d3c2h", NothingKept}, draws, 10, game.NewTripleDrawConfig())
+			require.Error(t, err)
+			require.Nil(t, config)
+		}
+
+		config, err := drawEquityConfig([]string{"7s5d3x"}, []int{1}, 10, game.NewTripleDrawConfig())
+		require.Error(t, err)
+		require.Nil(t, config)
+	})
+
+	t.Run("no board in draw games", func(t *testing.T) {
+		require.Nil(t, drawEquityCmd.Flags().Lookup("board"))
+		require.NotNil(t, handOddsCmd.Flags().Lookup("board"))
+		require.NotNil(t, rangeEquityCmd.Flags().Lookup("board"))
+	})
+}
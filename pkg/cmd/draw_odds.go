@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/anuarkaliyev23/goker/pkg/calc"
+	utils "github.com/anuarkaliyev23/goker/pkg/cmd/utils"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/fatih/color"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var combinationFlag string
+
+var drawOddsCmd = &cobra.Command{
+	Use: "draw-odds",
+	Short: "exact probability of making a combination or better by the river",
+	RunE: func(c *cobra.Command, args []string) error {
+		err, executionDuration := utils.MeasureTime(func() error {
+			config, err := drawOddsConfig(heroHandFlag, boardFlag, deadFlag, combinationFlag, selectedGameConfig())
+			if err != nil {
+				return err
+			}
+			config.Threads = threadsFlag
+
+			result, err := calc.DrawOdds(*config)
+			if err != nil {
+				return err
+			}
+
+			color.Yellow(fmt.Sprintf("%s or better: %.2f%%", utils.FormatCombinationType(config.CombinationType), result.Probability() * 100))
+			for _, combinationType := range lo.Reverse(append(result.CombinationStrengths()[:0:0], result.CombinationStrengths()...)) {
+				if result.CombinationTypes[combinationType] == 0 {
+					continue
+				}
+				color.White(fmt.Sprintf("    %s: %.2f%%", utils.FormatCombinationType(combinationType), result.TypeProbability(combinationType) * 100))
+			}
+			color.White(fmt.Sprintf("Exhaustive: %d runouts", result.RunoutsCount))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		color.White(fmt.Sprintf("%d ms\n", executionDuration))
+		return nil
+	},
+}
+
+func drawOddsConfig(handRepresentation string, boardRepresentation string, deadRepresentation string, combinationRepresentation string, gameConfig game.Config) (*calc.DrawOddsConfig, error) {
+	hand, err := utils.ParseCards(handRepresentation)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := utils.ParseCards(boardRepresentation)
+	if err != nil {
+		return nil, err
+	}
+
+	deadCards, err := utils.ParseCards(deadRepresentation)
+	if err != nil {
+		return nil, err
+	}
+
+	combinationType, err := utils.ParseCombinationType(combinationRepresentation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &calc.DrawOddsConfig{
+		Hand: hand,
+		Board: board,
+		DeadCards: deadCards,
+		CombinationType: combinationType,
+		GameConfig: gameConfig,
+	}, nil
+}
+
+func init() {
+	drawOddsCmd.Flags().StringVar(&heroHandFlag, "hand", "", "used to pass hole cards")
+	drawOddsCmd.Flags().StringVar(&boardFlag, "board", "", "used to pass community/board cards")
+	drawOddsCmd.Flags().StringVar(&deadFlag, "dead", "", "used to pass folded or exposed cards, which cannot be dealt")
+	drawOddsCmd.Flags().StringVar(&combinationFlag, "combination", "", "target combination, e.g. flush or full-house")
+	drawOddsCmd.Flags().IntVar(&threadsFlag, "threads", 0, "how much workers runouts are split between (defaults to the number of CPUs)")
+	drawOddsCmd.MarkFlagRequired("hand")
+	drawOddsCmd.MarkFlagRequired("combination")
+
+	addGameFlags(drawOddsCmd)
+
+	rootCmd.AddCommand(drawOddsCmd)
+}
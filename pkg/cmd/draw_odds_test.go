@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_drawOddsConfig(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		config, err := drawOddsConfig("AsKs", "Qs7s2d", "", "full-house", game.NewTexasConfig())
+		require.NoError(t, err)
+		require.Equal(t, cards.FullHouse, config.CombinationType)
+		require.Equal(t, 3, len(config.Board))
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		config, err := drawOddsConfig("AsKs", "Qs7s2d", "", "boat", game.NewTexasConfig())
+		require.Error(t, err)
+		require.Nil(t, config)
+	})
+}
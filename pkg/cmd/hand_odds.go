@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/anuarkaliyev23/goker/pkg/calc"
 	"github.com/anuarkaliyev23/goker/pkg/cards"
@@ -16,92 +20,516 @@ const (
 	TexasFlagName = "texas"
 	ShortDeckFlagName = "short-deck"
 	OmahaFlagName = "omaha"
+	Omaha5FlagName = "omaha5"
+	Omaha6FlagName = "omaha6"
+	OmahaHiLoFlagName = "omaha-hi-lo"
+	StudFlagName = "stud"
+	StudHiLoFlagName = "stud-hi-lo"
+	RazzFlagName = "razz"
+	PineappleFlagName = "pineapple"
+	CrazyPineappleFlagName = "crazy-pineapple"
 )
 
+var gameFlagNames = []string{TexasFlagName, ShortDeckFlagName, OmahaFlagName, Omaha5FlagName, Omaha6FlagName, OmahaHiLoFlagName, StudFlagName, StudHiLoFlagName, RazzFlagName, PineappleFlagName, CrazyPineappleFlagName}
+
+// Marks player, whose discard is picked for the best equity
+const UndecidedDiscard = "-"
+
 var boardFlag string
 var handsFlag []string
 var iterationsFlag int
+var exhaustiveFlag bool
+var threadsFlag int
+var seedFlag int64
+var vsRandomFlag int
+var deadFlag string
+var streetsFlag bool
+var combinationsFlag bool
+var outputFlag string
+var precisionFlag string
+var maxIterationsFlag int
+var timeLimitFlag time.Duration
+var discardsFlag []string
+var upCardsFlag []string
+var discardOptionsFlag bool
 
 var texasFlag bool
 var shortDeckFlag bool
 var omahaFlag bool
+var omaha5Flag bool
+var omaha6Flag bool
+var omahaHiLoFlag bool
+var studFlag bool
+var studHiLoFlag bool
+var razzFlag bool
+var pineappleFlag bool
+var crazyPineappleFlag bool
 
 var handOddsCmd = &cobra.Command{
 	Use: "hand-odds",
 	Short: "compare hand odds with optional board",
 	RunE: func(c *cobra.Command, args []string) error {
-		err, executionDuration := utils.MeasureTime(func() error {
-			var gameConfig game.Config
-			
-			if texasFlag {
-				gameConfig = game.NewTexasConfig()
-			} else if shortDeckFlag {
-				gameConfig = game.NewShortDeckConfig()
-			} else if omahaFlag {
-				gameConfig = game.NewOmahaConfig()
+		err := validateOutputFormat(outputFlag)
+		if err != nil {
+			return err
+		}
+		if outputFlag != TextOutput {
+			color.NoColor = true
+		}
+
+		gameConfig := selectedGameConfig()
+		hands := append(append([]string{}, handsFlag...), randomHands(vsRandomFlag, gameConfig)...)
+
+		handOddsConfig, err := handOddsConfig(boardFlag, hands, iterationsFlag, gameConfig)
+		if err != nil {
+			return err
+		}
+		handOddsConfig.Exhaustive = exhaustiveFlag
+		err = applySimulationFlags(c, handOddsConfig)
+		if err != nil {
+			return err
+		}
+		err = applyDiscards(handOddsConfig, discardsFlag)
+		if err != nil {
+			return err
+		}
+		err = applyUpCards(handOddsConfig, upCardsFlag)
+		if err != nil {
+			return err
+		}
+
+		if discardOptionsFlag && outputFlag != TextOutput {
+			return fmt.Errorf("Equity per discard option supports only {%s} output", TextOutput)
+		}
+
+		if streetsFlag {
+			if outputFlag != TextOutput {
+				return fmt.Errorf("Equity per street supports only {%s} output", TextOutput)
 			}
 
-			handOdds, err := handOdds(boardFlag, handsFlag, iterationsFlag, gameConfig)
+			ctx, cancel := simulationContext(c)
+			defer cancel()
+
+			err, executionDuration := utils.MeasureTime(func() error {
+				return printStreetEquities(ctx, *handOddsConfig, hands)
+			})
 			if err != nil {
 				return err
 			}
-			
-			playersWins, err := handOdds.WinRates()
+			color.White(fmt.Sprintf("%d ms\n", executionDuration))
+			return nil
+		}
+
+		ctx, cancel := simulationContext(c)
+		defer cancel()
+
+		var handOdds *calc.HandOddsResult
+		err, executionDuration := utils.MeasureTime(func() error {
+			handOdds, err = calc.HandOddsContext(ctx, *handOddsConfig)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if outputFlag != TextOutput {
+			report, err := newHandOddsReport(handOdds, hands, combinationsFlag, executionDuration)
 			if err != nil {
 				return err
 			}
-			
-			wonPlayer := lo.Max(playersWins)
-			wonPlayerIndex := lo.IndexOf(playersWins, wonPlayer)
-
-			for player := 0; player < len(playersWins); player++ {
-				s := fmt.Sprintf("[%v]: %.1f%%", handsFlag[player], playersWins[player] * 100)
-				if player == wonPlayerIndex {
-					color.Green(s)
-				} else {
-					color.Red(s)
-				}
+			return writeReport(c.OutOrStdout(), outputFlag, *report)
+		}
+
+		err = printHandOdds(handOdds, hands)
+		if err != nil {
+			return err
+		}
+		color.White(fmt.Sprintf("%d ms\n", executionDuration))
+
+		if discardOptionsFlag {
+			err, executionDuration = utils.MeasureTime(func() error {
+				return printDiscardEquities(ctx, *handOddsConfig, hands)
+			})
+			if err != nil {
+				return err
 			}
+			color.White(fmt.Sprintf("%d ms\n", executionDuration))
+		}
+		return nil
+	},
+}
+
+func printHandOdds(handOdds *calc.HandOddsResult, hands []string) error {
+	playersWins, err := handOdds.WinRates()
+	if err != nil {
+		return err
+	}
+	
+	equities := handOdds.Equities()
+	tieRates := handOdds.TieRates()
+	wonPlayerIndex := lo.IndexOf(equities, lo.Max(equities))
+
+	hiLo := handOdds.Config.GameConfig.HiLo
+	for player := 0; player < len(playersWins); player++ {
+		// most hi-lo pots are split between high and low hands, so they are broken down by split outcomes instead
+		s := fmt.Sprintf("[%v]: %s", hands[player], formatEquity(handOdds, player))
+		if !hiLo {
+			s += fmt.Sprintf(" (win: %.1f%%, tie: %.1f%%)", playersWins[player] * 100, tieRates[player] * 100)
+		}
+		if player == wonPlayerIndex {
+			color.Green(s)
+		} else {
+			color.Red(s)
+		}
+
+		if discard := handOdds.PlayerDiscard(player); len(discard) > 0 {
+			color.White(fmt.Sprintf("    Discard: %s", utils.FormatCards(discard)))
+		}
 
-			ties, err := handOdds.TiePercentage()
+		if hiLo {
+			err := printSplitStats(handOdds, player)
 			if err != nil {
 				return err
 			}
+		}
 
-			color.Yellow(fmt.Sprintf("Ties: %.1f%%", ties * 100))
-			return nil
-		})
+		if combinationsFlag {
+			err := printCombinationStats(handOdds, player)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if !hiLo {
+		ties, err := handOdds.TiePercentage()
 		if err != nil {
 			return err
 		}
-		color.White(fmt.Sprintf("%d ms\n", executionDuration))
+		color.Yellow(fmt.Sprintf("Ties: %.1f%%", ties * 100))
+	}
+
+	if handOdds.Exhaustive {
+		color.White(fmt.Sprintf("Exhaustive: %d runouts", handOdds.IterationsCount()))
+	} else if handOdds.Interrupted {
+		color.White(fmt.Sprintf("Time limit reached, stopped after %d iterations", handOdds.IterationsCount()))
+	} else if exhaustiveFlag && handOdds.Config.HasUnknownCards() {
+		color.White(fmt.Sprintf("Exhaustive mode does not support unknown cards, sampled %d iterations", handOdds.IterationsCount()))
+	} else if exhaustiveFlag {
+		color.White(fmt.Sprintf("Too many runouts for exhaustive mode, sampled %d iterations", handOdds.IterationsCount()))
+	}
+	printPrecision(handOdds)
+	return nil
+}
+
+// formatEquity formats player's equity along with margin of error at 95% confidence for sampled results
+func formatEquity(result *calc.HandOddsResult, player int) string {
+	equity := fmt.Sprintf("%.1f%%", result.Equities()[player] * 100)
+	if result.Exhaustive {
+		return equity
+	}
+	return fmt.Sprintf("%s ±%.1f%%", equity, result.MarginsOfError()[player] * 100)
+}
+
+func printPrecision(result *calc.HandOddsResult) {
+	if result.Exhaustive || result.Config.Precision == 0 || result.Interrupted {
+		return
+	}
+
+	if result.Precise() {
+		color.White(fmt.Sprintf("Precision ±%v%% reached after %d iterations", result.Config.Precision * 100, result.IterationsCount()))
+	} else {
+		color.White(fmt.Sprintf("Precision ±%v%% not reached, stopped after %d iterations", result.Config.Precision * 100, result.IterationsCount()))
+	}
+}
+
+func printCombinationStats(result *calc.HandOddsResult, player int) error {
+	stats, err := result.CombinationStats(player)
+	if err != nil {
+		return err
+	}
+
+	for _, stat := range lo.Reverse(stats) {
+		if stat.Frequency == 0 {
+			continue
+		}
+		color.White(fmt.Sprintf("    %s: %.1f%% (wins: %.1f%%, equity: %.1f%%)", utils.FormatCombinationType(stat.CombinationType), stat.Frequency * 100, stat.WinRate * 100, stat.Equity * 100))
+	}
+	return nil
+}
+
+func printSplitStats(result *calc.HandOddsResult, player int) error {
+	stats, err := result.SplitStats(player)
+	if err != nil {
+		return err
+	}
+
+	for _, stat := range stats {
+		if stat.Frequency == 0 {
+			continue
+		}
+		color.White(fmt.Sprintf("    %s: %.1f%% (equity: %.1f%%)", stat.Outcome, stat.Frequency * 100, stat.Equity * 100))
+	}
+	return nil
+}
+
+// printDiscardEquities prints equity of every discard option for each fully known hand
+func printDiscardEquities(ctx context.Context, config calc.HandOddsConfig, hands []string) error {
+	if config.GameConfig.DiscardsCount == 0 {
+		return errors.New("Discard options are only available in games with discards")
+	}
+
+	for player, hand := range config.Hands {
+		if len(hand) != config.GameConfig.HoleCardsCount {
+			continue
+		}
+
+		options, err := calc.DiscardEquitiesContext(ctx, config, player)
+		if err != nil {
+			return err
+		}
+
+		equities := lo.Map(options, func(option calc.DiscardEquity, _ int) string {
+			return fmt.Sprintf("discard %s: %.1f%%", utils.FormatCards(option.Discard), option.Equity * 100)
+		})
+		color.White(fmt.Sprintf("[%v]: %s", hands[player], strings.Join(equities, ", ")))
+	}
+	return nil
+}
+
+func formatStreet(street calc.Street) string {
+	switch street {
+	case calc.Preflop: return "Preflop"
+	case calc.Flop: return "Flop"
+	case calc.Turn: return "Turn"
+	default: return "River"
+	}
+}
+
+func printStreetEquities(ctx context.Context, config calc.HandOddsConfig, hands []string) error {
+	result, err := calc.StreetEquitiesContext(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	for _, street := range result.Streets {
+		equities := lo.Map(street.Equities(), func(equity float64, player int) string {
+			return fmt.Sprintf("[%v]: %.1f%%", hands[player], equity * 100)
+		})
+		label := formatStreet(street.Street)
+		if len(street.Board) > 0 {
+			label += " " + utils.FormatCards(street.Board)
+		}
+		color.White(fmt.Sprintf("%s: %s", label, strings.Join(equities, ", ")))
+	}
+
+	if result.Interrupted {
+		color.White("Time limit reached, later streets are not calculated")
+	}
+
+	swing, ok := result.BiggestSwing()
+	if !ok {
 		return nil
-	},
+	}
+	color.Yellow(fmt.Sprintf("Biggest swing: %s on the %s, [%v] %+.1f%%", utils.FormatCards(swing.Cards), strings.ToLower(formatStreet(swing.Street)), hands[swing.Player], swing.Change * 100))
+	return nil
+}
+
+func selectedGameConfig() game.Config {
+	var gameConfig game.Config
+
+	if texasFlag {
+		gameConfig = game.NewTexasConfig()
+	} else if shortDeckFlag {
+		gameConfig = game.NewShortDeckConfig()
+	} else if omahaFlag {
+		gameConfig = game.NewOmahaConfig()
+	} else if omaha5Flag {
+		gameConfig = game.NewOmaha5Config()
+	} else if omaha6Flag {
+		gameConfig = game.NewOmaha6Config()
+	} else if omahaHiLoFlag {
+		gameConfig = game.NewOmahaHiLoConfig()
+	} else if studFlag {
+		gameConfig = game.NewStudConfig()
+	} else if studHiLoFlag {
+		gameConfig = game.NewStudHiLoConfig()
+	} else if razzFlag {
+		gameConfig = game.NewRazzConfig()
+	} else if pineappleFlag {
+		gameConfig = game.NewPineappleConfig()
+	} else if crazyPineappleFlag {
+		gameConfig = game.NewCrazyPineappleConfig()
+	}
+
+	return gameConfig
+}
+
+func addGameFlags(c *cobra.Command) {
+	c.Flags().BoolVar(&texasFlag, TexasFlagName, false, "flag to indicate Texas Hold'em")
+	c.Flags().BoolVar(&shortDeckFlag, ShortDeckFlagName, false, "flag to indicate Short-Deck")
+	c.Flags().BoolVar(&omahaFlag, OmahaFlagName, false, "flag to indicate Omaha")
+	c.Flags().BoolVar(&omaha5Flag, Omaha5FlagName, false, "flag to indicate 5-card Pot Limit Omaha")
+	c.Flags().BoolVar(&omaha6Flag, Omaha6FlagName, false, "flag to indicate 6-card Pot Limit Omaha")
+	c.Flags().BoolVar(&omahaHiLoFlag, OmahaHiLoFlagName, false, "flag to indicate Omaha Hi-Lo eight-or-better")
+	c.Flags().BoolVar(&studFlag, StudFlagName, false, "flag to indicate Seven Card Stud, hands are three down cards with --up-cards or seven cards each, \"?\" for unseen ones")
+	c.Flags().BoolVar(&studHiLoFlag, StudHiLoFlagName, false, "flag to indicate Seven Card Stud Hi-Lo eight-or-better")
+	c.Flags().BoolVar(&razzFlag, RazzFlagName, false, "flag to indicate Razz")
+	c.Flags().BoolVar(&pineappleFlag, PineappleFlagName, false, "flag to indicate Pineapple, where one of three hole cards is discarded before the flop")
+	c.Flags().BoolVar(&crazyPineappleFlag, CrazyPineappleFlagName, false, "flag to indicate Crazy Pineapple, where one of three hole cards is discarded after the flop")
+
+	c.MarkFlagsOneRequired(gameFlagNames...)
+	c.MarkFlagsMutuallyExclusive(gameFlagNames...)
+}
+
+// addSimulationFlags adds flags shared by every command running a simulation, board is added only by commands of games with one
+func addSimulationFlags(c *cobra.Command) {
+	c.Flags().StringVar(&deadFlag, "dead", "", "used to pass folded or exposed cards, which cannot be dealt")
+	c.Flags().IntVarP(&iterationsFlag, "iterations", "i", 1000, "how much iterations should simulation have")
+	c.Flags().IntVar(&threadsFlag, "threads", 0, "how much workers simulation is split between (defaults to the number of CPUs)")
+	c.Flags().Int64Var(&seedFlag, "seed", 0, "seed for random generator, makes results repeatable")
+	c.Flags().StringVar(&precisionFlag, "precision", "", "keep sampling until 95% confidence interval of every equity is within given margin, e.g. 0.1%")
+	c.Flags().IntVar(&maxIterationsFlag, "max-iterations", calc.DefaultMaxIterationsCount, "stop sampling with --precision after this number of iterations")
+	c.Flags().DurationVar(&timeLimitFlag, "time-limit", 0, "stop calculation after given time and show results reached so far, e.g. 2s (unlimited by default)")
+}
+
+// simulationContext limits calculation time by --time-limit if it is set
+func simulationContext(c *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx := c.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if timeLimitFlag > 0 {
+		return context.WithTimeout(ctx, timeLimitFlag)
+	}
+	return context.WithCancel(ctx)
+}
+
+func applySimulationFlags(c *cobra.Command, config *calc.HandOddsConfig) error {
+	deadCards, err := utils.ParseCards(deadFlag)
+	if err != nil {
+		return err
+	}
+
+	config.DeadCards = deadCards
+	config.Threads = threadsFlag
+	if c.Flags().Changed("seed") {
+		config.Seed = &seedFlag
+	}
+
+	if precisionFlag == "" && c.Flags().Changed("max-iterations") {
+		return errors.New("--max-iterations caps sampling only with --precision, use --iterations otherwise")
+	}
+
+	if precisionFlag != "" {
+		precision, err := utils.ParsePercentage(precisionFlag)
+		if err != nil {
+			return err
+		}
+		config.Precision = precision
+		config.MaxIterationsCount = maxIterationsFlag
+	}
+	return nil
 }
 
 func handOdds(boardRepresentation string, handsRepresentation []string, iterations int, gameConfig game.Config) (*calc.HandOddsResult, error) {
+	handOddsConfig, err := handOddsConfig(boardRepresentation, handsRepresentation, iterations, gameConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return calc.HandOdds(*handOddsConfig)
+}
+
+func handOddsConfig(boardRepresentation string, handsRepresentation []string, iterations int, gameConfig game.Config) (*calc.HandOddsConfig, error) {
 	boardCards, err := utils.ParseCards(boardRepresentation)
 	if err != nil {
 		return nil, err
 	}
 
-	hands := lo.Map(handsRepresentation, func(representation string, _ int) []cards.Card {
-		cards, err := utils.ParseCards(representation)
+	hands := [][]cards.Card{}
+	for _, representation := range handsRepresentation {
+		known, unknown, err := utils.ParseHand(representation)
 		if err != nil {
-			return nil
+			return nil, err
 		}
 
-		return cards
-	})
+		if gameConfig.UpCardsCount > 0 && len(known) + unknown == gameConfig.DownCardsCount() {
+			hands = append(hands, known)
+			continue
+		}
+
+		if len(known) + unknown != gameConfig.HoleCardsCount {
+			return nil, fmt.Errorf("Hand {%s} should have {%d} cards, use \"%s\" for unknown ones", representation, gameConfig.HoleCardsCount, utils.UnknownCard)
+		}
+
+		hands = append(hands, known)
+	}
 
-	handOddsConfig := calc.HandOddsConfig{
+	return &calc.HandOddsConfig{
 		Board: boardCards,
 		Hands: hands,
 		IterationsCount: iterations,
 		GameConfig: gameConfig,
+	}, nil
+}
+
+// applyDiscards sets discards chosen for players of --hands, players without a chosen discard are marked with UndecidedDiscard
+func applyDiscards(config *calc.HandOddsConfig, discardsRepresentation []string) error {
+	if len(discardsRepresentation) == 0 {
+		return nil
+	}
+
+	if len(discardsRepresentation) > len(config.Hands) {
+		return fmt.Errorf("Discards are given for {%d} players, but there are only {%d}", len(discardsRepresentation), len(config.Hands))
 	}
 
-	return calc.HandOdds(handOddsConfig)
+	discards := make([][]cards.Card, len(config.Hands))
+	for player, representation := range discardsRepresentation {
+		if representation == UndecidedDiscard {
+			continue
+		}
+
+		discard, err := utils.ParseCards(representation)
+		if err != nil {
+			return err
+		}
+		discards[player] = discard
+	}
+
+	config.Discards = discards
+	return nil
+}
+
+// applyUpCards sets face up cards of every player of --hands, whose hands are then their down cards
+func applyUpCards(config *calc.HandOddsConfig, upCardsRepresentation []string) error {
+	if len(upCardsRepresentation) == 0 {
+		return nil
+	}
+
+	if len(upCardsRepresentation) != len(config.Hands) {
+		return fmt.Errorf("Up cards are given for {%d} players, expected {%d}", len(upCardsRepresentation), len(config.Hands))
+	}
+
+	upCards := [][]cards.Card{}
+	for _, representation := range upCardsRepresentation {
+		playerUpCards, err := utils.ParseCards(representation)
+		if err != nil {
+			return err
+		}
+		upCards = append(upCards, playerUpCards)
+	}
+
+	config.UpCards = upCards
+	return nil
+}
+
+// randomHands represents count fully unknown hands
+func randomHands(count int, gameConfig game.Config) []string {
+	return lo.Times(count, func(_ int) string {
+		return strings.Repeat(utils.UnknownCard, gameConfig.HoleCardsCount)
+	})
 }
 
 func countPlayerWins(handOdds *calc.HandOddsResult, handsRepresentation []string) ([]int, error) {
@@ -119,16 +547,19 @@ func countPlayerWins(handOdds *calc.HandOddsResult, handsRepresentation []string
 }
 
 func init() {
+	handOddsCmd.Flags().StringSliceVarP(&handsFlag, "hands", "", nil, "used to pass hole/hand cards, unknown cards are marked with \"?\", e.g. Ks?")
 	handOddsCmd.Flags().StringVar(&boardFlag, "board", "", "used to pass community/board cards")
-	handOddsCmd.Flags().StringSliceVarP(&handsFlag, "hands", "", nil, "used to pass hole/hand cards")
-	handOddsCmd.Flags().IntVarP(&iterationsFlag, "iterations", "i", 1000, "how much iterations should simulation have")
-
-	handOddsCmd.Flags().BoolVar(&texasFlag, TexasFlagName, false, "flag to indicate Texas Hold'em")
-	handOddsCmd.Flags().BoolVar(&shortDeckFlag, ShortDeckFlagName, false, "flag to indicate Short-Deck")
-	handOddsCmd.Flags().BoolVar(&omahaFlag, OmahaFlagName, false, "flag to indicate Omaha")
+	addSimulationFlags(handOddsCmd)
+	handOddsCmd.Flags().IntVar(&vsRandomFlag, "vs-random", 0, "add given number of opponents with random hands")
+	handOddsCmd.Flags().StringVar(&outputFlag, "output", TextOutput, "output format: text, json, csv or yaml")
+	handOddsCmd.Flags().BoolVar(&combinationsFlag, "combinations", false, "show how often every player finishes with each combination and how often it wins")
+	handOddsCmd.Flags().BoolVar(&streetsFlag, "streets", false, "show equity preflop, on the flop, turn and river of complete board")
+	handOddsCmd.Flags().StringSliceVar(&discardsFlag, "discards", nil, "used to pass cards every player discards in Pineapple games, \"-\" lets player discard for the best equity")
+	handOddsCmd.Flags().StringSliceVar(&upCardsFlag, "up-cards", nil, "used to pass face up cards of every player in stud games, then --hands are their down cards")
+	handOddsCmd.Flags().BoolVar(&discardOptionsFlag, "discard-options", false, "show equity of every discard option for each three-card hand")
+	handOddsCmd.Flags().BoolVar(&exhaustiveFlag, "exhaustive", false, "enumerate every possible runout instead of sampling (falls back to sampling if there are too many)")
 
-	handOddsCmd.MarkFlagsOneRequired(TexasFlagName, ShortDeckFlagName, OmahaFlagName)
-	handOddsCmd.MarkFlagsMutuallyExclusive(TexasFlagName, ShortDeckFlagName, OmahaFlagName)
+	addGameFlags(handOddsCmd)
 
 	rootCmd.AddCommand(handOddsCmd)
 }
@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
+	"github.com/anuarkaliyev23/goker/pkg/calc"
 	"github.com/anuarkaliyev23/goker/pkg/cards"
 	"github.com/anuarkaliyev23/goker/pkg/game"
 	"github.com/samber/lo"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,3 +62,153 @@ func Test_handOdds(t *testing.T) {
 
 	})
 }
+
+func Test_rangeEquityConfig(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		config, err := rangeEquityConfig("Kd7h2c", []string{"AKs", "QQ+,AKo:0.5"}, 10, game.NewTexasConfig())
+		require.NoError(t, err)
+		require.Equal(t, 2, config.PlayersCount())
+		require.Equal(t, 4, len(config.Ranges[0]))
+		require.Equal(t, 3 * 6 + 12, len(config.Ranges[1]))
+		require.Equal(t, 3, len(config.Board))
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		config, err := rangeEquityConfig("", []string{"AKs", "QQ++"}, 10, game.NewTexasConfig())
+		require.Error(t, err)
+		require.Nil(t, config)
+	})
+}
+
+func Test_handOddsConfig(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		hands := append([]string{"AsAd", "Ks?"}, randomHands(2, game.NewTexasConfig())...)
+		config, err := handOddsConfig("", hands, 10, game.NewTexasConfig())
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 1, 0, 0}, lo.Map(config.Hands, func(hand []cards.Card, _ int) int {
+			return len(hand)
+		}))
+		require.True(t, config.HasUnknownCards())
+	})
+
+	t.Run("big omaha", func(t *testing.T) {
+		config, err := handOddsConfig("", []string{"AsAdKsKd2c", "?????"}, 10, game.NewOmaha5Config())
+		require.NoError(t, err)
+		require.Equal(t, game.Omaha5, config.GameConfig.Game)
+
+		_, err = handOddsConfig("", []string{"AsAdKsKd2c", "??????"}, 10, game.NewOmaha6Config())
+		require.Error(t, err)
+	})
+
+	t.Run("stud", func(t *testing.T) {
+		config, err := handOddsConfig("", []string{"AsAdKc7h???", "??QsQd9c??"}, 10, game.NewRazzConfig())
+		require.NoError(t, err)
+		require.Equal(t, []int{4, 3}, lo.Map(config.Hands, func(hand []cards.Card, _ int) int {
+			return len(hand)
+		}))
+
+		_, err = handOddsConfig("", []string{"AsAdKc7h", "??QsQd9c??"}, 10, game.NewStudConfig())
+		require.Error(t, err)
+	})
+
+	t.Run("stud up cards", func(t *testing.T) {
+		config, err := handOddsConfig("", []string{"AsAd?", "???"}, 10, game.NewStudConfig())
+		require.NoError(t, err)
+
+		err = applyUpCards(config, []string{"Kc7h", "QsQd"})
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 2}, lo.Map(config.UpCards, func(upCards []cards.Card, _ int) int {
+			return len(upCards)
+		}))
+		require.Equal(t, []int{2, 0}, lo.Map(config.Hands, func(hand []cards.Card, _ int) int {
+			return len(hand)
+		}))
+
+		err = applyUpCards(config, []string{"Kc7h"})
+		require.Error(t, err)
+
+		_, err = handOddsConfig("", []string{"AsAd?", "???"}, 10, game.NewTexasConfig())
+		require.Error(t, err)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		for _, hand := range []string{"Ks", "Ks??", "Kx?"} {
+			t.Run(hand, func(t *testing.T) {
+				config, err := handOddsConfig("", []string{"AsAd", hand}, 10, game.NewTexasConfig())
+				require.Error(t, err)
+				require.Nil(t, config)
+			})
+		}
+	})
+}
+
+func Test_applyDiscards(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		config, err := handOddsConfig("", []string{"AsAd2c", "KsKd3h", "???"}, 10, game.NewPineappleConfig())
+		require.NoError(t, err)
+
+		err = applyDiscards(config, []string{UndecidedDiscard, "3h"})
+		require.NoError(t, err)
+		require.Len(t, config.Discards, 3)
+		require.Empty(t, config.Discards[0])
+		require.Equal(t, 1, len(config.Discards[1]))
+		require.Empty(t, config.Discards[2])
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		config, err := handOddsConfig("", []string{"AsAd2c"}, 10, game.NewPineappleConfig())
+		require.NoError(t, err)
+
+		require.Error(t, applyDiscards(config, []string{"2c", "3h"}))
+		require.Error(t, applyDiscards(config, []string{"2x"}))
+	})
+}
+
+func Test_applySimulationFlags(t *testing.T) {
+	newCommand := func() *cobra.Command {
+		c := &cobra.Command{}
+		addSimulationFlags(c)
+		return c
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		c := newCommand()
+		require.NoError(t, c.ParseFlags([]string{"--precision", "0.5%", "--max-iterations", "5000"}))
+		defer func() { precisionFlag = ""; maxIterationsFlag = calc.DefaultMaxIterationsCount }()
+
+		config := &calc.HandOddsConfig{}
+		require.NoError(t, applySimulationFlags(c, config))
+		require.InDelta(t, 0.005, config.Precision, 1e-12)
+		require.Equal(t, 5000, config.MaxIterationsCount)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		c := newCommand()
+		require.NoError(t, c.ParseFlags([]string{"--max-iterations", "5000"}))
+		defer func() { maxIterationsFlag = calc.DefaultMaxIterationsCount }()
+
+		require.Error(t, applySimulationFlags(c, &calc.HandOddsConfig{}))
+	})
+}
+
+func Test_simulationContext(t *testing.T) {
+	t.Run("without time limit", func(t *testing.T) {
+		ctx, cancel := simulationContext(handOddsCmd)
+		defer cancel()
+
+		_, hasDeadline := ctx.Deadline()
+		require.False(t, hasDeadline)
+	})
+
+	t.Run("with time limit", func(t *testing.T) {
+		timeLimitFlag = time.Second
+		defer func() { timeLimitFlag = 0 }()
+
+		ctx, cancel := simulationContext(handOddsCmd)
+		defer cancel()
+
+		deadline, hasDeadline := ctx.Deadline()
+		require.True(t, hasDeadline)
+		require.WithinDuration(t, time.Now().Add(time.Second), deadline, 100 * time.Millisecond)
+	})
+}
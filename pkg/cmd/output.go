@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/anuarkaliyev23/goker/pkg/calc"
+	utils "github.com/anuarkaliyev23/goker/pkg/cmd/utils"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	TextOutput = "text"
+	JsonOutput = "json"
+	CsvOutput = "csv"
+	YamlOutput = "yaml"
+)
+
+var outputFormats = []string{TextOutput, JsonOutput, CsvOutput, YamlOutput}
+
+func validateOutputFormat(format string) error {
+	if !lo.Contains(outputFormats, format) {
+		return fmt.Errorf("Unknown output format {%s}, should be one of {%v}", format, outputFormats)
+	}
+	return nil
+}
+
+type combinationReport struct {
+	Type string `json:"type" yaml:"type"`
+	Frequency float64 `json:"frequency" yaml:"frequency"`
+	WinRate float64 `json:"win_rate" yaml:"win_rate"`
+	Equity float64 `json:"equity" yaml:"equity"`
+}
+
+type splitReport struct {
+	Outcome string `json:"outcome" yaml:"outcome"`
+	Frequency float64 `json:"frequency" yaml:"frequency"`
+	Equity float64 `json:"equity" yaml:"equity"`
+}
+
+type confidenceIntervalReport struct {
+	Low float64 `json:"low" yaml:"low"`
+	High float64 `json:"high" yaml:"high"`
+}
+
+type playerReport struct {
+	Hand string `json:"hand" yaml:"hand"`
+	Equity float64 `json:"equity" yaml:"equity"`
+	// Win and tie rates are left out in hi-lo games, where most pots are split between high and low hands, see Splits
+	Win *float64 `json:"win,omitempty" yaml:"win,omitempty"`
+	Tie *float64 `json:"tie,omitempty" yaml:"tie,omitempty"`
+	StandardError float64 `json:"standard_error" yaml:"standard_error"`
+	// 95% confidence interval of equity
+	ConfidenceInterval confidenceIntervalReport `json:"confidence_interval" yaml:"confidence_interval"`
+	Combinations []combinationReport `json:"combinations,omitempty" yaml:"combinations,omitempty"`
+	// Scoop, high only, low only and quartered outcomes, only for hi-lo games
+	Splits []splitReport `json:"splits,omitempty" yaml:"splits,omitempty"`
+	// Cards discarded in games with discards, if chosen or picked for the best equity
+	Discard string `json:"discard,omitempty" yaml:"discard,omitempty"`
+}
+
+// handOddsReport is a stable schema of hand-odds results for machine-readable outputs
+type handOddsReport struct {
+	Game string `json:"game" yaml:"game"`
+	Board string `json:"board" yaml:"board"`
+	DeadCards string `json:"dead_cards" yaml:"dead_cards"`
+	Iterations int `json:"iterations" yaml:"iterations"`
+	Exhaustive bool `json:"exhaustive" yaml:"exhaustive"`
+	// true if calculation was stopped by time limit
+	Interrupted bool `json:"interrupted" yaml:"interrupted"`
+	Players []playerReport `json:"players" yaml:"players"`
+	// Left out in hi-lo games, just like win and tie rates of players
+	Ties *float64 `json:"ties,omitempty" yaml:"ties,omitempty"`
+	ElapsedMs int64 `json:"elapsed_ms" yaml:"elapsed_ms"`
+}
+
+func formatGame(g game.Game) string {
+	switch g {
+	case game.Texas: return TexasFlagName
+	case game.ShortDeck: return ShortDeckFlagName
+	case game.Omaha: return OmahaFlagName
+	case game.Omaha5: return Omaha5FlagName
+	case game.Omaha6: return Omaha6FlagName
+	case game.OmahaHiLo: return OmahaHiLoFlagName
+	case game.Stud: return StudFlagName
+	case game.StudHiLo: return StudHiLoFlagName
+	case game.Razz: return RazzFlagName
+	case game.TripleDraw: return TripleDrawFlagName
+	case game.FiveCardDraw: return FiveCardDrawFlagName
+	case game.Pineapple: return PineappleFlagName
+	case game.CrazyPineapple: return CrazyPineappleFlagName
+	default: return "custom"
+	}
+}
+
+func formatSplitOutcome(outcome calc.SplitOutcome) string {
+	switch outcome {
+	case calc.Scoop: return "scoop"
+	case calc.ThreeQuarters: return "three_quarters"
+	case calc.HighOnly: return "high_only"
+	case calc.LowOnly: return "low_only"
+	case calc.Chopped: return "chopped"
+	default: return "quartered"
+	}
+}
+
+func newHandOddsReport(result *calc.HandOddsResult, hands []string, withCombinations bool, elapsedMs int64) (*handOddsReport, error) {
+	iterations := float64(result.IterationsCount())
+	equities := result.Equities()
+	tieRates := result.TieRates()
+	standardErrors := result.StandardErrors()
+	intervals := result.ConfidenceIntervals()
+	players := []playerReport{}
+	for player := range equities {
+		report := playerReport{
+			Hand: hands[player],
+			Equity: equities[player],
+			StandardError: standardErrors[player],
+			ConfidenceInterval: confidenceIntervalReport{
+				Low: intervals[player].Low,
+				High: intervals[player].High,
+			},
+			Discard: utils.FormatCards(result.PlayerDiscard(player)),
+		}
+
+		if withCombinations {
+			stats, err := result.CombinationStats(player)
+			if err != nil {
+				return nil, err
+			}
+
+			report.Combinations = lo.Map(stats, func(stat calc.CombinationStat, _ int) combinationReport {
+				return combinationReport{
+					Type: utils.FormatCombinationType(stat.CombinationType),
+					Frequency: stat.Frequency,
+					WinRate: stat.WinRate,
+					Equity: stat.Equity,
+				}
+			})
+		}
+
+		if !result.Config.GameConfig.HiLo {
+			report.Win = lo.ToPtr(float64(result.Accumulator.Wins[player]) / iterations)
+			report.Tie = lo.ToPtr(tieRates[player])
+		} else {
+			stats, err := result.SplitStats(player)
+			if err != nil {
+				return nil, err
+			}
+
+			report.Splits = lo.Map(stats, func(stat calc.SplitStat, _ int) splitReport {
+				return splitReport{
+					Outcome: formatSplitOutcome(stat.Outcome),
+					Frequency: stat.Frequency,
+					Equity: stat.Equity,
+				}
+			})
+		}
+		players = append(players, report)
+	}
+
+	var ties *float64
+	if !result.Config.GameConfig.HiLo {
+		ties = lo.ToPtr(float64(result.Accumulator.Ties) / iterations)
+	}
+
+	return &handOddsReport{
+		Game: formatGame(result.Config.GameConfig.Game),
+		Board: utils.FormatCards(result.Config.Board),
+		DeadCards: utils.FormatCards(result.Config.DeadCards),
+		Iterations: result.IterationsCount(),
+		Exhaustive: result.Exhaustive,
+		Interrupted: result.Interrupted,
+		Players: players,
+		Ties: ties,
+		ElapsedMs: elapsedMs,
+	}, nil
+}
+
+var csvHeader = []string{"game", "board", "dead_cards", "iterations", "exhaustive", "player", "hand", "equity", "win", "tie", "elapsed_ms", "standard_error", "confidence_low", "confidence_high", "interrupted"}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatOptionalFloat formats value left out of the report as an empty cell
+func formatOptionalFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return formatFloat(*value)
+}
+
+// writeReport writes report in one of machine-readable formats, CSV has a row per player
+func writeReport(w io.Writer, format string, report handOddsReport) error {
+	switch format {
+	case JsonOutput:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case YamlOutput:
+		encoder := yaml.NewEncoder(w)
+		defer encoder.Close()
+		return encoder.Encode(report)
+	case CsvOutput:
+		writer := csv.NewWriter(w)
+		err := writer.Write(csvHeader)
+		if err != nil {
+			return err
+		}
+
+		for player, playerReport := range report.Players {
+			err := writer.Write([]string{
+				report.Game,
+				report.Board,
+				report.DeadCards,
+				strconv.Itoa(report.Iterations),
+				strconv.FormatBool(report.Exhaustive),
+				strconv.Itoa(player),
+				playerReport.Hand,
+				formatFloat(playerReport.Equity),
+				formatOptionalFloat(playerReport.Win),
+				formatOptionalFloat(playerReport.Tie),
+				strconv.FormatInt(report.ElapsedMs, 10),
+				formatFloat(playerReport.StandardError),
+				formatFloat(playerReport.ConfidenceInterval.Low),
+				formatFloat(playerReport.ConfidenceInterval.High),
+				strconv.FormatBool(report.Interrupted),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("Cannot write report in {%s} format", format)
+	}
+}
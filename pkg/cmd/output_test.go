@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func testReport(t *testing.T, withCombinations bool) handOddsReport {
+	result, err := handOdds("Kh7c2d5s", []string{"AsAd", "KsKd"}, 100, game.NewTexasConfig())
+	require.NoError(t, err)
+	require.False(t, result.Exhaustive)
+
+	report, err := newHandOddsReport(result, []string{"AsAd", "KsKd"}, withCombinations, 7)
+	require.NoError(t, err)
+	return *report
+}
+
+func Test_newHandOddsReport(t *testing.T) {
+	report := testReport(t, true)
+	require.Equal(t, "texas", report.Game)
+	require.Equal(t, "Kh7c2d5s", report.Board)
+	require.Equal(t, 100, report.Iterations)
+	require.Equal(t, int64(7), report.ElapsedMs)
+	require.Equal(t, 2, len(report.Players))
+	require.Equal(t, "KsKd", report.Players[1].Hand)
+	require.InDelta(t, 1.0, report.Players[0].Equity + report.Players[1].Equity, 1e-9)
+	require.Equal(t, 9, len(report.Players[0].Combinations))
+	for _, player := range report.Players {
+		require.Greater(t, player.StandardError, 0.0)
+		require.Less(t, player.ConfidenceInterval.Low, player.Equity)
+		require.Greater(t, player.ConfidenceInterval.High, player.Equity)
+	}
+}
+
+func Test_newHandOddsReport_HiLo(t *testing.T) {
+	result, err := handOdds("3c4h9d", []string{"As2sKdQd", "JhTh9c8c"}, 100, game.NewOmahaHiLoConfig())
+	require.NoError(t, err)
+
+	report, err := newHandOddsReport(result, []string{"As2sKdQd", "JhTh9c8c"}, false, 0)
+	require.NoError(t, err)
+	require.Equal(t, "omaha-hi-lo", report.Game)
+	require.Nil(t, report.Ties)
+	for _, player := range report.Players {
+		require.Nil(t, player.Win)
+		require.Nil(t, player.Tie)
+		require.Equal(t, []string{"scoop", "three_quarters", "high_only", "low_only", "chopped", "quartered"}, lo.Map(player.Splits, func(split splitReport, _ int) string {
+			return split.Outcome
+		}))
+		require.InDelta(t, player.Equity, lo.SumBy(player.Splits, func(split splitReport) float64 {
+			return split.Equity
+		}), 1e-9)
+	}
+}
+
+func Test_writeReport(t *testing.T) {
+	report := testReport(t, false)
+
+	t.Run("positive", func(t *testing.T) {
+		t.Run("json", func(t *testing.T) {
+			var buffer bytes.Buffer
+			require.NoError(t, writeReport(&buffer, JsonOutput, report))
+
+			var parsed map[string]any
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &parsed))
+			require.Equal(t, "texas", parsed["game"])
+			require.Equal(t, 100.0, parsed["iterations"])
+			require.NotContains(t, buffer.String(), "combinations")
+
+			var decoded handOddsReport
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+			require.Equal(t, report, decoded)
+		})
+
+		t.Run("yaml", func(t *testing.T) {
+			var buffer bytes.Buffer
+			require.NoError(t, writeReport(&buffer, YamlOutput, report))
+
+			var decoded handOddsReport
+			require.NoError(t, yaml.Unmarshal(buffer.Bytes(), &decoded))
+			require.Equal(t, report, decoded)
+		})
+
+		t.Run("csv", func(t *testing.T) {
+			var buffer bytes.Buffer
+			require.NoError(t, writeReport(&buffer, CsvOutput, report))
+
+			rows, err := csv.NewReader(&buffer).ReadAll()
+			require.NoError(t, err)
+			require.Equal(t, 3, len(rows))
+			require.Equal(t, csvHeader, rows[0])
+			require.Equal(t, []string{"texas", "Kh7c2d5s", "", "100", "false", "1", "KsKd"}, rows[2][:7])
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		var buffer bytes.Buffer
+		require.Error(t, writeReport(&buffer, "xml", report))
+		require.Error(t, validateOutputFormat("xml"))
+		require.NoError(t, validateOutputFormat(TextOutput))
+	})
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anuarkaliyev23/goker/pkg/calc"
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	utils "github.com/anuarkaliyev23/goker/pkg/cmd/utils"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/fatih/color"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var heroHandFlag string
+var opponentsFlag []string
+
+var outsCmd = &cobra.Command{
+	Use: "outs",
+	Short: "list cards improving hand from behind to ahead on flop or turn",
+	RunE: func(c *cobra.Command, args []string) error {
+		err, executionDuration := utils.MeasureTime(func() error {
+			config, err := outsConfig(heroHandFlag, opponentsFlag, boardFlag, deadFlag, selectedGameConfig())
+			if err != nil {
+				return err
+			}
+
+			result, err := calc.Outs(*config)
+			if err != nil {
+				return err
+			}
+
+			printOuts(result)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		color.White(fmt.Sprintf("%d ms\n", executionDuration))
+		return nil
+	},
+}
+
+func formatOuts(outs []calc.Out) string {
+	return strings.Join(lo.Map(outs, func(out calc.Out, _ int) string {
+		s := utils.FormatCards([]cards.Card{out.Card})
+		if !out.Clean() {
+			s += "*"
+		}
+		return s
+	}), " ")
+}
+
+func printOuts(result *calc.OutsResult) {
+	for _, combinationType := range result.CombinationTypes() {
+		outs := result.OutsOfType(combinationType)
+		color.Green(fmt.Sprintf("%s (%d): %s", utils.FormatCombinationType(combinationType), len(outs), formatOuts(outs)))
+	}
+
+	outsCount := len(result.Outs)
+	cleanCount := len(result.CleanOuts())
+	color.Yellow(fmt.Sprintf("Outs: %d (clean: %d, tainted: %d)", outsCount, cleanCount, outsCount - cleanCount))
+
+	if result.CardsToCome() > 1 {
+		color.White(fmt.Sprintf("Turn: %.1f%% (clean: %.1f%%)", result.NextCardProbability(outsCount) * 100, result.NextCardProbability(cleanCount) * 100))
+		color.White(fmt.Sprintf("By river: %.1f%% (clean: %.1f%%)", result.HitProbability(outsCount) * 100, result.HitProbability(cleanCount) * 100))
+	} else {
+		color.White(fmt.Sprintf("River: %.1f%% (clean: %.1f%%)", result.HitProbability(outsCount) * 100, result.HitProbability(cleanCount) * 100))
+	}
+}
+
+// parseOpponent parses exact hand of any size or a range
+func parseOpponent(representation string) (cards.Range, error) {
+	hand, err := utils.ParseCards(representation)
+	if err == nil && len(hand) > 0 {
+		return cards.Range{{Cards: hand, Weight: 1}}, nil
+	}
+
+	return utils.ParseRange(representation)
+}
+
+func outsConfig(handRepresentation string, opponentsRepresentation []string, boardRepresentation string, deadRepresentation string, gameConfig game.Config) (*calc.OutsConfig, error) {
+	hand, err := utils.ParseCards(handRepresentation)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := utils.ParseCards(boardRepresentation)
+	if err != nil {
+		return nil, err
+	}
+
+	deadCards, err := utils.ParseCards(deadRepresentation)
+	if err != nil {
+		return nil, err
+	}
+
+	opponents := []cards.Range{}
+	for _, representation := range opponentsRepresentation {
+		r, err := parseOpponent(representation)
+		if err != nil {
+			return nil, err
+		}
+		opponents = append(opponents, r)
+	}
+
+	return &calc.OutsConfig{
+		Hand: hand,
+		Opponents: opponents,
+		Board: board,
+		DeadCards: deadCards,
+		GameConfig: gameConfig,
+	}, nil
+}
+
+func init() {
+	outsCmd.Flags().StringVar(&heroHandFlag, "hand", "", "used to pass hole cards of the hero")
+	outsCmd.Flags().StringArrayVar(&opponentsFlag, "opponents", nil, "hand or range of an opponent, repeat flag for every opponent")
+	outsCmd.Flags().StringVar(&boardFlag, "board", "", "used to pass flop or turn")
+	outsCmd.Flags().StringVar(&deadFlag, "dead", "", "used to pass folded or exposed cards, which cannot be dealt")
+	outsCmd.MarkFlagRequired("hand")
+	outsCmd.MarkFlagRequired("board")
+
+	addGameFlags(outsCmd)
+
+	rootCmd.AddCommand(outsCmd)
+}
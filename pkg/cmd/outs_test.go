@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_outsConfig(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		config, err := outsConfig("AsKs", []string{"QdQc", "JJ+,AQs"}, "Qs7s2d", "3s", game.NewTexasConfig())
+		require.NoError(t, err)
+		require.Equal(t, 2, len(config.Hand))
+		require.Equal(t, 1, len(config.Opponents[0]))
+		require.Equal(t, 4 * 6 + 4, len(config.Opponents[1]))
+		require.Equal(t, 3, len(config.Board))
+		require.Equal(t, 1, len(config.DeadCards))
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		config, err := outsConfig("AsKs", []string{"QQ++"}, "Qs7s2d", "", game.NewTexasConfig())
+		require.Error(t, err)
+		require.Nil(t, config)
+
+		config, err = outsConfig("AsK", []string{"QQ"}, "Qs7s2d", "", game.NewTexasConfig())
+		require.Error(t, err)
+		require.Nil(t, config)
+	})
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/anuarkaliyev23/goker/pkg/calc"
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	utils "github.com/anuarkaliyev23/goker/pkg/cmd/utils"
+	"github.com/anuarkaliyev23/goker/pkg/game"
+	"github.com/fatih/color"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var rangesFlag []string
+var combosFlag bool
+
+var rangeEquityCmd = &cobra.Command{
+	Use: "range-equity",
+	Short: "compare equity of hands or ranges (e.g. \"AKs,TT+\") with optional board",
+	RunE: func(c *cobra.Command, args []string) error {
+		ctx, cancel := simulationContext(c)
+		defer cancel()
+
+		err, executionDuration := utils.MeasureTime(func() error {
+			config, err := rangeEquityConfig(boardFlag, rangesFlag, iterationsFlag, selectedGameConfig())
+			if err != nil {
+				return err
+			}
+			err = applySimulationFlags(c, config)
+			if err != nil {
+				return err
+			}
+
+			result, err := calc.HandOddsContext(ctx, *config)
+			if err != nil {
+				return err
+			}
+
+			equities := result.Equities()
+			winnerIndex := lo.IndexOf(equities, lo.Max(equities))
+
+			for player := range equities {
+				s := fmt.Sprintf("[%v]: %s", rangesFlag[player], formatEquity(result, player))
+				if player == winnerIndex {
+					color.Green(s)
+				} else {
+					color.Red(s)
+				}
+
+				if combosFlag {
+					err := printComboEquities(result, player)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			if result.Interrupted {
+				color.White(fmt.Sprintf("Time limit reached, stopped after %d iterations", result.IterationsCount()))
+			}
+			printPrecision(result)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		color.White(fmt.Sprintf("%d ms\n", executionDuration))
+		return nil
+	},
+}
+
+func printComboEquities(result *calc.HandOddsResult, player int) error {
+	combos, err := result.ComboEquities(player)
+	if err != nil {
+		return err
+	}
+
+	for _, combo := range combos {
+		if combo.IterationsCount == 0 {
+			continue
+		}
+		color.White(fmt.Sprintf("    %s: %.1f%% (%d iterations)", utils.FormatCards(combo.Hand), combo.Equity * 100, combo.IterationsCount))
+	}
+	return nil
+}
+
+func rangeEquityConfig(boardRepresentation string, rangesRepresentation []string, iterations int, gameConfig game.Config) (*calc.HandOddsConfig, error) {
+	boardCards, err := utils.ParseCards(boardRepresentation)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := []cards.Range{}
+	for _, representation := range rangesRepresentation {
+		r, err := utils.ParseRange(representation)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+
+	return &calc.HandOddsConfig{
+		Board: boardCards,
+		Ranges: ranges,
+		IterationsCount: iterations,
+		GameConfig: gameConfig,
+	}, nil
+}
+
+func init() {
+	rangeEquityCmd.Flags().StringArrayVar(&rangesFlag, "ranges", nil, "range of a player, repeat flag for every player")
+	rangeEquityCmd.Flags().StringVar(&boardFlag, "board", "", "used to pass community/board cards")
+	rangeEquityCmd.Flags().BoolVar(&combosFlag, "combos", false, "print equity of every combo in the ranges")
+	addSimulationFlags(rangeEquityCmd)
+
+	rangeEquityCmd.Flags().BoolVar(&texasFlag, TexasFlagName, false, "flag to indicate Texas Hold'em")
+	rangeEquityCmd.Flags().BoolVar(&shortDeckFlag, ShortDeckFlagName, false, "flag to indicate Short-Deck")
+	rangeEquityCmd.MarkFlagsOneRequired(TexasFlagName, ShortDeckFlagName)
+	rangeEquityCmd.MarkFlagsMutuallyExclusive(TexasFlagName, ShortDeckFlagName)
+
+	rootCmd.AddCommand(rangeEquityCmd)
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/anuarkaliyev23/goker/pkg/cards"
@@ -95,3 +96,105 @@ func ParseCards(representation string) ([]cards.Card, error) {
 	
 	return parsed, nil
 }
+
+const UnknownCard = "?"
+
+// ParseHand parses hole cards, where every unknown card is replaced by "?", e.g. "Ks?" or "??".
+// Returns known cards and the number of unknown ones
+func ParseHand(representation string) ([]cards.Card, int, error) {
+	known := []cards.Card{}
+	unknown := 0
+
+	for i := 0; i < len(representation); {
+		if strings.HasPrefix(representation[i:], UnknownCard) {
+			unknown++
+			i += len(UnknownCard)
+			continue
+		}
+
+		if i + validCardStringSize > len(representation) {
+			return nil, 0, fmt.Errorf("Cannot parse hand from {%s}, card at {%d} is incomplete", representation, i)
+		}
+
+		card, err := ParseCard(representation[i:i + validCardStringSize])
+		if err != nil {
+			return nil, 0, err
+		}
+
+		known = append(known, *card)
+		i += validCardStringSize
+	}
+
+	return known, unknown, nil
+}
+
+func formatFace(face cards.Face) string {
+	switch face {
+	case cards.Ace: return Ace
+	case cards.King: return King
+	case cards.Queen: return Queen
+	case cards.Jack: return Jack
+	case cards.Ten: return Ten
+	case cards.Nine: return Nine
+	case cards.Eight: return Eight
+	case cards.Seven: return Seven
+	case cards.Six: return Six
+	case cards.Five: return Five
+	case cards.Four: return Four
+	case cards.Three: return Three
+	default: return Two
+	}
+}
+
+func formatSuit(suit cards.Suit) string {
+	switch suit {
+	case cards.Clubs: return strings.ToLower(Clubs)
+	case cards.Spades: return strings.ToLower(Spades)
+	case cards.Diamonds: return strings.ToLower(Diamonds)
+	default: return strings.ToLower(Hearts)
+	}
+}
+
+// FormatCards is the reverse of ParseCards, e.g. "KsTh"
+func FormatCards(cs []cards.Card) string {
+	var builder strings.Builder
+	for _, card := range cs {
+		builder.WriteString(formatFace(card.Face()))
+		builder.WriteString(formatSuit(card.Suit()))
+	}
+	return builder.String()
+}
+
+func FormatCombinationType(combinationType cards.CombinationType) string {
+	switch combinationType {
+	case cards.HighCard: return "High Card"
+	case cards.Pair: return "Pair"
+	case cards.TwoPair: return "Two Pair"
+	case cards.ThreeOfAKind: return "Three of a Kind"
+	case cards.Straight: return "Straight"
+	case cards.Flush: return "Flush"
+	case cards.FullHouse: return "Full House"
+	case cards.FourOfAKind: return "Four of a Kind"
+	default: return "Straight Flush"
+	}
+}
+
+// ParseCombinationType is the reverse of FormatCombinationType, accepts e.g. "flush", "Full House" or "full-house"
+func ParseCombinationType(representation string) (cards.CombinationType, error) {
+	normalized := strings.ReplaceAll(strings.ToLower(representation), "-", " ")
+	for _, combinationType := range cards.DefaultCombinationStrength {
+		if strings.ToLower(FormatCombinationType(combinationType)) == normalized {
+			return combinationType, nil
+		}
+	}
+	return 0, fmt.Errorf("Cannot parse combination type from {%s}", representation)
+}
+
+// ParsePercentage parses percentage like "0.1%" into a fraction, percent sign is optional
+func ParsePercentage(representation string) (float64, error) {
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(representation), "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot parse percentage from {%s}", representation)
+	}
+	return percentage / 100, nil
+}
@@ -259,3 +259,88 @@ func TestParseCards(t *testing.T) {
 		})
 	})
 }
+
+func TestFormatCards(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		require.Equal(t, "", FormatCards([]cards.Card{}))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, representation := range []string{"KsTh", "Ac2d9c", "JhQd5s4c"} {
+			parsed, err := ParseCards(representation)
+			require.NoError(t, err)
+			require.Equal(t, representation, FormatCards(parsed))
+		}
+	})
+}
+
+func TestParseHand(t *testing.T) {
+	t.Run("valid cases", func(t *testing.T) {
+		t.Run("known hand", func(t *testing.T) {
+			known, unknown, err := ParseHand("KsTh")
+			require.NoError(t, err)
+			require.Equal(t, "KsTh", FormatCards(known))
+			require.Equal(t, 0, unknown)
+		})
+
+		t.Run("partially known hand", func(t *testing.T) {
+			known, unknown, err := ParseHand("Ks?")
+			require.NoError(t, err)
+			require.Equal(t, "Ks", FormatCards(known))
+			require.Equal(t, 1, unknown)
+		})
+
+		t.Run("unknown hand", func(t *testing.T) {
+			known, unknown, err := ParseHand("??")
+			require.NoError(t, err)
+			require.Empty(t, known)
+			require.Equal(t, 2, unknown)
+		})
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		for _, representation := range []string{"K?s", "Ks?T", "Kx?"} {
+			t.Run(representation, func(t *testing.T) {
+				known, _, err := ParseHand(representation)
+				require.Error(t, err)
+				require.Nil(t, known)
+			})
+		}
+	})
+}
+
+func TestParseCombinationType(t *testing.T) {
+	t.Run("valid cases", func(t *testing.T) {
+		for _, combinationType := range cards.DefaultCombinationStrength {
+			parsed, err := ParseCombinationType(FormatCombinationType(combinationType))
+			require.NoError(t, err)
+			require.Equal(t, combinationType, parsed)
+		}
+
+		parsed, err := ParseCombinationType("full-house")
+		require.NoError(t, err)
+		require.Equal(t, cards.FullHouse, parsed)
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		_, err := ParseCombinationType("fullhouse")
+		require.Error(t, err)
+	})
+}
+
+func TestParsePercentage(t *testing.T) {
+	t.Run("valid cases", func(t *testing.T) {
+		for representation, expected := range map[string]float64{"0.1%": 0.001, "1": 0.01, " 50% ": 0.5} {
+			parsed, err := ParsePercentage(representation)
+			require.NoError(t, err)
+			require.InDelta(t, expected, parsed, 1e-12)
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		for _, representation := range []string{"", "%", "one%", "0.1%%"} {
+			_, err := ParsePercentage(representation)
+			require.Error(t, err)
+		}
+	})
+}
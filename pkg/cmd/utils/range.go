@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+)
+
+const rangeSeparator = ","
+const weightSeparator = ":"
+const plusSuffix = "+"
+const dashSeparator = "-"
+
+const suitedSuffix = "S"
+const offsuitSuffix = "O"
+
+const defaultWeight = 1.0
+
+type suitedness int
+
+const (
+	anySuits suitedness = iota
+	suited
+	offsuit
+)
+
+// handClass is a group of hole cards combos such as AKs, TT or QJ
+type handClass struct {
+	high cards.Face
+	low cards.Face
+	suitedness suitedness
+}
+
+func (h handClass) isPair() bool {
+	return h.high == h.low
+}
+
+func (h handClass) hands() [][]cards.Card {
+	hands := [][]cards.Card{}
+	for i, firstSuit := range cards.Suits {
+		for j, secondSuit := range cards.Suits {
+			if h.isPair() && j <= i {
+				continue
+			}
+			if h.suitedness == suited && firstSuit != secondSuit {
+				continue
+			}
+			if h.suitedness == offsuit && firstSuit == secondSuit {
+				continue
+			}
+
+			first, _ := cards.NewCard(h.high, firstSuit)
+			second, _ := cards.NewCard(h.low, secondSuit)
+			hands = append(hands, []cards.Card{*first, *second})
+		}
+	}
+	return hands
+}
+
+func parseHandClass(representation string) (*handClass, error) {
+	if len(representation) != 2 && len(representation) != 3 {
+		return nil, fmt.Errorf("Cannot parse hand class from {%s}", representation)
+	}
+	representation = strings.ToUpper(representation)
+
+	high, err := parseFace(string(representation[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	low, err := parseFace(string(representation[1]))
+	if err != nil {
+		return nil, err
+	}
+
+	if high < low {
+		high, low = low, high
+	}
+
+	class := handClass{high: high, low: low, suitedness: anySuits}
+
+	if len(representation) == 3 {
+		switch string(representation[2]) {
+		case suitedSuffix: class.suitedness = suited
+		case offsuitSuffix: class.suitedness = offsuit
+		default: return nil, fmt.Errorf("Cannot parse suitedness from {%s}, should be either s or o", representation)
+		}
+
+		if class.isPair() {
+			return nil, fmt.Errorf("Pair cannot be suited or offsuit {%s}", representation)
+		}
+	}
+
+	return &class, nil
+}
+
+// plusRange expands TT+ to TT-AA and ATs+ to ATs-AKs
+func plusRange(class handClass) []handClass {
+	classes := []handClass{}
+	if class.isPair() {
+		for face := class.high; face <= cards.Ace; face++ {
+			classes = append(classes, handClass{high: face, low: face})
+		}
+		return classes
+	}
+
+	for face := class.low; face < class.high; face++ {
+		classes = append(classes, handClass{high: class.high, low: face, suitedness: class.suitedness})
+	}
+	return classes
+}
+
+// dashRange expands 22-55 to 22, 33, 44, 55 and A2s-A5s to A2s, A3s, A4s, A5s
+func dashRange(from handClass, to handClass, representation string) ([]handClass, error) {
+	classes := []handClass{}
+	if from.isPair() && to.isPair() {
+		low, high := min(from.high, to.high), max(from.high, to.high)
+		for face := low; face <= high; face++ {
+			classes = append(classes, handClass{high: face, low: face})
+		}
+		return classes, nil
+	}
+
+	if from.isPair() || to.isPair() || from.high != to.high || from.suitedness != to.suitedness {
+		return nil, fmt.Errorf("Cannot parse range {%s}, both ends should be pairs or share the highest card", representation)
+	}
+
+	low, high := min(from.low, to.low), max(from.low, to.low)
+	for face := low; face <= high; face++ {
+		classes = append(classes, handClass{high: from.high, low: face, suitedness: from.suitedness})
+	}
+	return classes, nil
+}
+
+func parseRangeEntry(representation string) ([][]cards.Card, error) {
+	if specific, err := ParseCards(representation); err == nil && len(specific) == 2 {
+		if specific[0] == specific[1] {
+			return nil, fmt.Errorf("Cannot parse combo {%s} of two identical cards", representation)
+		}
+		return [][]cards.Card{specific}, nil
+	}
+
+	var classes []handClass
+
+	if strings.HasSuffix(representation, plusSuffix) {
+		class, err := parseHandClass(strings.TrimSuffix(representation, plusSuffix))
+		if err != nil {
+			return nil, err
+		}
+		classes = plusRange(*class)
+	} else if strings.Contains(representation, dashSeparator) {
+		ends := strings.Split(representation, dashSeparator)
+		if len(ends) != 2 {
+			return nil, fmt.Errorf("Cannot parse range {%s}, should have exactly two ends", representation)
+		}
+
+		from, err := parseHandClass(ends[0])
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := parseHandClass(ends[1])
+		if err != nil {
+			return nil, err
+		}
+
+		classes, err = dashRange(*from, *to, representation)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		class, err := parseHandClass(representation)
+		if err != nil {
+			return nil, err
+		}
+		classes = []handClass{*class}
+	}
+
+	hands := [][]cards.Card{}
+	for _, class := range classes {
+		hands = append(hands, class.hands()...)
+	}
+	return hands, nil
+}
+
+func parseWeight(representation string) (string, float64, error) {
+	notation, weightRepresentation, found := strings.Cut(representation, weightSeparator)
+	if !found {
+		return representation, defaultWeight, nil
+	}
+
+	weight, err := strconv.ParseFloat(weightRepresentation, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("Cannot parse weight from {%s}", representation)
+	}
+
+	if weight <= 0 || weight > 1 {
+		return "", 0, fmt.Errorf("Weight of {%s} should be in (0, 1] range", representation)
+	}
+
+	return notation, weight, nil
+}
+
+// ParseRange parses hold'em range in standard notation, e.g. "TT+,A2s-A5s,KQo,AsKh,AKs:0.5"
+// into concrete hole cards combos. If combo is mentioned several times, the last weight wins
+func ParseRange(representation string) (cards.Range, error) {
+	result := cards.Range{}
+	indexes := map[[2]cards.Card]int{}
+
+	for _, entry := range strings.Split(representation, rangeSeparator) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			return nil, fmt.Errorf("Cannot parse range from {%s}, it contains empty entry", representation)
+		}
+
+		notation, weight, err := parseWeight(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		hands, err := parseRangeEntry(notation)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hand := range hands {
+			key := [2]cards.Card{hand[0], hand[1]}
+			if index, ok := indexes[key]; ok {
+				result[index].Weight = weight
+				continue
+			}
+
+			// the same combo can be written in reversed order
+			if index, ok := indexes[[2]cards.Card{hand[1], hand[0]}]; ok {
+				result[index].Weight = weight
+				continue
+			}
+
+			indexes[key] = len(result)
+			result = append(result, cards.WeightedHand{Cards: hand, Weight: weight})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func handsOf(r cards.Range) []string {
+	return lo.Map(r, func(hand cards.WeightedHand, _ int) string {
+		return FormatCards(hand.Cards)
+	})
+}
+
+func TestParseRange(t *testing.T) {
+	t.Run("valid cases", func(t *testing.T) {
+		t.Run("pair", func(t *testing.T) {
+			parsed, err := ParseRange("AA")
+			require.NoError(t, err)
+			require.Equal(t, 6, len(parsed))
+			require.Equal(t, 6.0, parsed.TotalWeight())
+		})
+
+		t.Run("suited", func(t *testing.T) {
+			parsed, err := ParseRange("AKs")
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{"AcKc", "AdKd", "AsKs", "AhKh"}, handsOf(parsed))
+		})
+
+		t.Run("offsuit", func(t *testing.T) {
+			parsed, err := ParseRange("KQo")
+			require.NoError(t, err)
+			require.Equal(t, 12, len(parsed))
+			lo.ForEach(parsed, func(hand cards.WeightedHand, _ int) {
+				require.NotEqual(t, hand.Cards[0].Suit(), hand.Cards[1].Suit())
+			})
+		})
+
+		t.Run("any suits, reversed faces", func(t *testing.T) {
+			parsed, err := ParseRange("ka")
+			require.NoError(t, err)
+			require.Equal(t, 16, len(parsed))
+			require.Equal(t, cards.Ace, parsed[0].Cards[0].Face())
+		})
+
+		t.Run("pairs plus", func(t *testing.T) {
+			parsed, err := ParseRange("TT+")
+			require.NoError(t, err)
+			require.Equal(t, 5 * 6, len(parsed))
+		})
+
+		t.Run("kickers plus", func(t *testing.T) {
+			parsed, err := ParseRange("ATs+")
+			require.NoError(t, err)
+			faces := lo.Uniq(lo.Map(parsed, func(hand cards.WeightedHand, _ int) cards.Face {
+				return hand.Cards[1].Face()
+			}))
+			require.Equal(t, []cards.Face{cards.Ten, cards.Jack, cards.Queen, cards.King}, faces)
+			require.Equal(t, 16, len(parsed))
+		})
+
+		t.Run("pairs dash", func(t *testing.T) {
+			parsed, err := ParseRange("55-22")
+			require.NoError(t, err)
+			require.Equal(t, 4 * 6, len(parsed))
+		})
+
+		t.Run("kickers dash", func(t *testing.T) {
+			parsed, err := ParseRange("A2s-A5s")
+			require.NoError(t, err)
+			require.Equal(t, 4 * 4, len(parsed))
+		})
+
+		t.Run("specific combo", func(t *testing.T) {
+			parsed, err := ParseRange("AsKh")
+			require.NoError(t, err)
+			require.Equal(t, []string{"AsKh"}, handsOf(parsed))
+		})
+
+		t.Run("weights", func(t *testing.T) {
+			parsed, err := ParseRange("AKs:0.5, QQ")
+			require.NoError(t, err)
+			require.Equal(t, 10, len(parsed))
+			require.Equal(t, 4 * 0.5 + 6, parsed.TotalWeight())
+		})
+
+		t.Run("duplicates keep the last weight", func(t *testing.T) {
+			parsed, err := ParseRange("AK,AsKs:0.25,KhAh:0.5")
+			require.NoError(t, err)
+			require.Equal(t, 16, len(parsed))
+			require.Equal(t, 14 + 0.25 + 0.5, parsed.TotalWeight())
+		})
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		invalid := []string{
+			"",
+			"AK,,QQ",
+			"AAs",
+			"AKx",
+			"A",
+			"AKQ+",
+			"22-AKs",
+			"A2s-K5s",
+			"A2s-A5o",
+			"AK:0",
+			"AK:1.5",
+			"AK:half",
+			"AhAh",
+			"1A",
+		}
+
+		for _, representation := range invalid {
+			t.Run(representation, func(t *testing.T) {
+				parsed, err := ParseRange(representation)
+				require.Error(t, err)
+				require.Nil(t, parsed)
+			})
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package evaluator
+
+import "github.com/anuarkaliyev23/goker/pkg/cards"
+
+// Card is a compact integer representation of cards.Card (Cactus Kev's encoding):
+//
+//	xxxbbbbb bbbbbbbb cdhsrrrr xxpppppp
+//
+// b - bit turned on for the face, cdhs - suit bit, r - face number, p - face prime
+type Card uint32
+
+var facePrimes = []uint32{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+var suitBits = map[cards.Suit]uint32{
+	cards.Clubs: 0x8000,
+	cards.Diamonds: 0x4000,
+	cards.Hearts: 0x2000,
+	cards.Spades: 0x1000,
+}
+
+const suitMask = 0xF000
+const primeMask = 0xFF
+
+func NewCard(card cards.Card) Card {
+	face := uint32(card.Face())
+	return Card(1 << (16 + face) | suitBits[card.Suit()] | face << 8 | facePrimes[face])
+}
+
+func NewCards(cs []cards.Card) []Card {
+	result := make([]Card, len(cs))
+	for i, card := range cs {
+		result[i] = NewCard(card)
+	}
+	return result
+}
+
+func (c Card) Face() cards.Face {
+	return cards.Face((c >> 8) & 0xF)
+}
+
+func (c Card) prime() uint32 {
+	return uint32(c) & primeMask
+}
+
+func (c Card) faceBit() uint32 {
+	return uint32(c) >> 16
+}
@@ -0,0 +1,210 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+// Rank is a comparable strength of the best 5-card hand, bigger rank wins.
+// Ranks are only comparable between hands evaluated by the same Evaluator
+type Rank uint32
+
+const positionShift = 24
+
+func (r Rank) Type() cards.CombinationType {
+	return cards.CombinationType((r >> typeShift) & 0xF)
+}
+
+type Evaluator struct {
+	tables *tables
+	positions []Rank
+	// Worse high hand is the better one, as in deuce-to-seven lowball
+	lowball bool
+}
+
+var Default = NewEvaluator(cards.DefaultCombinationStrength, false)
+var ShortDeck = NewEvaluator(cards.ShortDeckCombinationStrength, true)
+// DeuceToSeven ranks hands of deuce-to-seven lowball, where the worst high hand wins and ace is always high
+var DeuceToSeven = newDeuceToSevenEvaluator()
+
+func NewEvaluator(combinationStrengths []cards.CombinationType, shortDeck bool) Evaluator {
+	positions := make([]Rank, cards.StraightFlush + 1)
+	for i, ctype := range combinationStrengths {
+		positions[ctype] = Rank(i)
+	}
+
+	t := defaultTables
+	if shortDeck {
+		t = shortDeckTables
+	}
+
+	return Evaluator{
+		tables: t,
+		positions: positions,
+	}
+}
+
+func newDeuceToSevenEvaluator() Evaluator {
+	e := NewEvaluator(cards.DeuceToSevenCombinationStrength, false)
+	e.tables = deuceToSevenTables
+	e.lowball = true
+	return e
+}
+
+func (e Evaluator) evaluate5(c1, c2, c3, c4, c5 Card) Rank {
+	return e.evaluateCombined(c1 | c2 | c3 | c4 | c5, c1 & c2 & c3 & c4 & c5, c1.prime() * c2.prime() * c3.prime() * c4.prime() * c5.prime())
+}
+
+// evaluateCombined evaluates five cards by bitwise OR and AND of them and product of their face primes
+func (e Evaluator) evaluateCombined(or Card, and Card, product uint32) Rank {
+	index := or >> 16
+
+	var value uint32
+	if and & suitMask != 0 {
+		value = e.tables.flushes[index]
+	} else if unique := e.tables.uniques[index]; unique != 0 {
+		value = unique
+	} else {
+		value = e.tables.products.get(product)
+	}
+
+	if e.lowball {
+		// lower faces make the better low, so faces are inverted while type is kept
+		value ^= 1 << typeShift - 1
+	}
+
+	return e.positions[value >> typeShift] << positionShift | Rank(value)
+}
+
+// partialHand is a part of 5-card hand reduced to what evaluateCombined needs,
+// so parts can be evaluated together without going through their cards again
+type partialHand struct {
+	or Card
+	and Card
+	product uint32
+}
+
+// Largest number of subsets precomputed by splitSubsets, 3 of 7 cards
+const maxSplitSubsets = 35
+
+// appendPartialHands appends partial hand of every subset of cs to partials
+func appendPartialHands(partials []partialHand, cs []Card, subsets [][]int) []partialHand {
+	for _, subset := range subsets {
+		partial := partialHand{and: suitMask, product: 1}
+		for _, index := range subset {
+			partial.or |= cs[index]
+			partial.and &= cs[index]
+			partial.product *= cs[index].prime()
+		}
+		partials = append(partials, partial)
+	}
+	return partials
+}
+
+// Precomputed 5-card subsets for the most common hand sizes
+var subsets = map[int][][]int{
+	5: combin.Combinations(5, cards.CombinationLength),
+	6: combin.Combinations(6, cards.CombinationLength),
+	7: combin.Combinations(7, cards.CombinationLength),
+}
+
+func subsetsOf(size int) [][]int {
+	if precomputed, ok := subsets[size]; ok {
+		return precomputed
+	}
+	return combin.Combinations(size, cards.CombinationLength)
+}
+
+// Strongest returns rank of the best 5-card hand and indexes of the cards it consists of
+func (e Evaluator) Strongest(cs []Card) (Rank, []int, error) {
+	if len(cs) < cards.CombinationLength {
+		return 0, nil, fmt.Errorf("Cannot evaluate hand of {%d} cards, must be at least {%d}", len(cs), cards.CombinationLength)
+	}
+
+	var best Rank
+	var bestSubset []int
+	for _, s := range subsetsOf(len(cs)) {
+		rank := e.evaluate5(cs[s[0]], cs[s[1]], cs[s[2]], cs[s[3]], cs[s[4]])
+		if rank > best {
+			best = rank
+			bestSubset = s
+		}
+	}
+
+	return best, bestSubset, nil
+}
+
+// Precomputed k-subsets of up to 7 cards, used to pick hole and board cards separately
+const maxPrecomputedSubsetsSize = 7
+
+var splitSubsets = precomputeSplitSubsets()
+
+func precomputeSplitSubsets() [][][][]int {
+	result := make([][][][]int, maxPrecomputedSubsetsSize + 1)
+	for n := range result {
+		result[n] = make([][][]int, cards.CombinationLength + 1)
+		for k := 0; k <= cards.CombinationLength && k <= n; k++ {
+			result[n][k] = combin.Combinations(n, k)
+		}
+	}
+	return result
+}
+
+func splitSubsetsOf(n int, k int) [][]int {
+	if n <= maxPrecomputedSubsetsSize {
+		return splitSubsets[n][k]
+	}
+	return combin.Combinations(n, k)
+}
+
+func validateSplit(hole []Card, holeCount int, board []Card, boardCount int) error {
+	if holeCount + boardCount != cards.CombinationLength || holeCount < 0 || boardCount < 0 {
+		return fmt.Errorf("Cannot evaluate hand of {%d} hole and {%d} board cards, must be {%d} in total", holeCount, boardCount, cards.CombinationLength)
+	}
+
+	if len(hole) < holeCount || len(board) < boardCount {
+		return fmt.Errorf("Cannot pick {%d} of {%d} hole and {%d} of {%d} board cards", holeCount, len(hole), boardCount, len(board))
+	}
+	return nil
+}
+
+// StrongestOf returns rank of the best 5-card hand made of exactly holeCount cards of hole and boardCount cards of board,
+// along with indexes of hole and board cards it consists of
+func (e Evaluator) StrongestOf(hole []Card, holeCount int, board []Card, boardCount int) (Rank, []int, []int, error) {
+	err := validateSplit(hole, holeCount, board, boardCount)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	var best Rank
+	var bestHole, bestBoard []int
+
+	holeSubsets := splitSubsetsOf(len(hole), holeCount)
+	boardSubsets := splitSubsetsOf(len(board), boardCount)
+	var holeBuffer, boardBuffer [maxSplitSubsets]partialHand
+	holePartials := appendPartialHands(holeBuffer[:0], hole, holeSubsets)
+	boardPartials := appendPartialHands(boardBuffer[:0], board, boardSubsets)
+	for i, h := range holePartials {
+		for j, b := range boardPartials {
+			rank := e.evaluateCombined(h.or | b.or, h.and & b.and, h.product * b.product)
+			if bestHole == nil || rank > best {
+				best = rank
+				bestHole = holeSubsets[i]
+				bestBoard = boardSubsets[j]
+			}
+		}
+	}
+
+	return best, bestHole, bestBoard, nil
+}
+
+func (e Evaluator) Evaluate(cs []Card) (Rank, error) {
+	rank, _, err := e.Strongest(cs)
+	return rank, err
+}
+
+func (e Evaluator) EvaluateCards(cs []cards.Card) (Rank, error) {
+	return e.Evaluate(NewCards(cs))
+}
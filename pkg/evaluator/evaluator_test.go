@@ -0,0 +1,288 @@
+package evaluator
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	cmd "github.com/anuarkaliyev23/goker/pkg/cmd/utils"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+func rankOf(e Evaluator, representation string) Rank {
+	cs, err := cmd.ParseCards(representation)
+	if err != nil {
+		panic(err)
+	}
+
+	rank, err := e.EvaluateCards(cs)
+	if err != nil {
+		panic(err)
+	}
+	return rank
+}
+
+func TestEvaluator_Type(t *testing.T) {
+	cases := map[string]cards.CombinationType{
+		"AsKsQsJsTs": cards.StraightFlush,
+		"5d4d3d2dAd": cards.StraightFlush,
+		"9s9d9h9cKs": cards.FourOfAKind,
+		"9s9d9hKcKs": cards.FullHouse,
+		"As8s6s4s2s": cards.Flush,
+		"Ts9d8h7c6s": cards.Straight,
+		"5s4d3h2cAs": cards.Straight,
+		"9s9d9hKcQs": cards.ThreeOfAKind,
+		"9s9dKhKcQs": cards.TwoPair,
+		"9s9dKhJcQs": cards.Pair,
+		"9s2dKhJcQs": cards.HighCard,
+		"As6d7h8c9s": cards.HighCard,
+	}
+
+	for representation, expected := range cases {
+		t.Run(representation, func(t *testing.T) {
+			require.Equal(t, expected, rankOf(Default, representation).Type())
+		})
+	}
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("combination types are ordered", func(t *testing.T) {
+			ordered := []string{
+				"9s2dKhJcQs",
+				"9s9dKhJcQs",
+				"9s9dKhKcQs",
+				"9s9d9hKcQs",
+				"Ts9d8h7c6s",
+				"As8s6s4s2s",
+				"9s9d9hKcKs",
+				"9s9d9h9cKs",
+				"AsKsQsJsTs",
+			}
+
+			for i := 1; i < len(ordered); i++ {
+				require.Less(t, rankOf(Default, ordered[i - 1]), rankOf(Default, ordered[i]))
+			}
+		})
+
+		t.Run("wheel is the lowest straight", func(t *testing.T) {
+			require.Less(t, rankOf(Default, "5s4d3h2cAs"), rankOf(Default, "6s5d4h3c2s"))
+		})
+
+		t.Run("kickers", func(t *testing.T) {
+			require.Less(t, rankOf(Default, "KsKdQh7c2s"), rankOf(Default, "KhKcQs8c2d"))
+			require.Less(t, rankOf(Default, "KsKd7h7cAs"), rankOf(Default, "KhKc8s8c2d"))
+			require.Equal(t, rankOf(Default, "KsKdQh7c2s"), rankOf(Default, "KhKcQs7d2d"))
+		})
+
+		t.Run("7 cards", func(t *testing.T) {
+			require.Equal(t, rankOf(Default, "9s9d9hKcKs"), rankOf(Default, "9s9d9hKcKs2d3c"))
+			require.Equal(t, cards.Flush, rankOf(Default, "As8s6s4s2sAdAh").Type())
+		})
+
+		t.Run("6 cards", func(t *testing.T) {
+			require.Equal(t, cards.Straight, rankOf(Default, "Ts9d8h7c6s2c").Type())
+		})
+
+		t.Run("short-deck", func(t *testing.T) {
+			require.Equal(t, cards.Straight, rankOf(ShortDeck, "As6d7h8c9s").Type())
+			require.Less(t, rankOf(ShortDeck, "As6d7h8c9s"), rankOf(ShortDeck, "Ts6d7h8c9s"))
+			require.Less(t, rankOf(ShortDeck, "9s9d9hKcKs"), rankOf(ShortDeck, "Ks8s6s7sJs"))
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Run("not enough cards", func(t *testing.T) {
+			_, err := Default.Evaluate(NewCards([]cards.Card{}))
+			require.Error(t, err)
+		})
+	})
+}
+
+func TestEvaluator_DistinctRanks(t *testing.T) {
+	deck := cards.NewFullDeck()
+	all := NewCards(deck.LeftCards())
+
+	distinct := map[Rank]cards.CombinationType{}
+	generator := combin.NewCombinationGenerator(len(all), cards.CombinationLength)
+	s := make([]int, cards.CombinationLength)
+	for generator.Next() {
+		generator.Combination(s)
+		rank := Default.evaluate5(all[s[0]], all[s[1]], all[s[2]], all[s[3]], all[s[4]])
+		distinct[rank] = rank.Type()
+	}
+
+	perType := map[cards.CombinationType]int{}
+	for _, ctype := range distinct {
+		perType[ctype]++
+	}
+
+	require.Equal(t, 7462, len(distinct))
+	require.Equal(t, map[cards.CombinationType]int{
+		cards.StraightFlush: 10,
+		cards.FourOfAKind: 156,
+		cards.FullHouse: 156,
+		cards.Flush: 1277,
+		cards.Straight: 10,
+		cards.ThreeOfAKind: 858,
+		cards.TwoPair: 858,
+		cards.Pair: 2860,
+		cards.HighCard: 1277,
+	}, perType)
+}
+
+func TestEvaluator_AgreesWithCombination(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 300; i++ {
+		deck := cards.NewFullDeck()
+		deck.ShuffleWith(random)
+		left := deck.LeftCards()
+		first := left[:7]
+		second := left[7:14]
+
+		firstCombination, err := cards.StrongestCombinationOf(append([]cards.Card{}, first...), cards.DefaultCombinationStrength, false)
+		require.NoError(t, err)
+		secondCombination, err := cards.StrongestCombinationOf(append([]cards.Card{}, second...), cards.DefaultCombinationStrength, false)
+		require.NoError(t, err)
+
+		firstRank, err := Default.EvaluateCards(first)
+		require.NoError(t, err)
+		secondRank, err := Default.EvaluateCards(second)
+		require.NoError(t, err)
+
+		require.Equal(t, firstCombination.Type(), firstRank.Type())
+		require.Equal(t, firstCombination.Less(*secondCombination), firstRank < secondRank, "%v vs %v", first, second)
+		require.Equal(t, firstCombination.Tie(*secondCombination), firstRank == secondRank, "%v vs %v", first, second)
+	}
+}
+
+func TestDeuceToSeven(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("ordering", func(t *testing.T) {
+			// from the best low to the worst one
+			ordered := []string{"7s5d4c3h2s", "7s6d4c3h2s", "8s5d4c3h2s", "Ks5d4c3h2s", "As5d4c3h2s", "2s2d4c3h5s", "7s6d5c4h3s", "7h5h4h3h2h", "AsAdAcKsKd"}
+			for i := 1; i < len(ordered); i++ {
+				require.Greater(t, rankOf(DeuceToSeven, ordered[i - 1]), rankOf(DeuceToSeven, ordered[i]), "%s should be better than %s", ordered[i - 1], ordered[i])
+			}
+		})
+
+		t.Run("ace is high", func(t *testing.T) {
+			require.Equal(t, cards.HighCard, rankOf(DeuceToSeven, "As5d4c3h2s").Type())
+			require.Equal(t, cards.Straight, rankOf(DeuceToSeven, "AsKdQcJhTs").Type())
+		})
+
+		t.Run("agrees with combination", func(t *testing.T) {
+			random := rand.New(rand.NewSource(1))
+
+			for i := 0; i < 300; i++ {
+				deck := cards.NewFullDeck()
+				deck.ShuffleWith(random)
+				left := deck.LeftCards()
+				first := left[:6]
+				second := left[6:12]
+
+				firstCombination, err := cards.StrongestLowballCombinationOf(append([]cards.Card{}, first...), cards.DeuceToSevenRanking)
+				require.NoError(t, err)
+				secondCombination, err := cards.StrongestLowballCombinationOf(append([]cards.Card{}, second...), cards.DeuceToSevenRanking)
+				require.NoError(t, err)
+
+				firstRank, err := DeuceToSeven.EvaluateCards(first)
+				require.NoError(t, err)
+				secondRank, err := DeuceToSeven.EvaluateCards(second)
+				require.NoError(t, err)
+
+				require.Equal(t, firstCombination.Type(), firstRank.Type())
+				require.Equal(t, firstCombination.Less(*secondCombination), firstRank < secondRank, "%v vs %v", first, second)
+				require.Equal(t, firstCombination.Tie(*secondCombination), firstRank == secondRank, "%v vs %v", first, second)
+			}
+		})
+	})
+}
+
+func TestEvaluator_StrongestOf(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		t.Run("agrees with brute force", func(t *testing.T) {
+			random := rand.New(rand.NewSource(1))
+
+			for i := 0; i < 300; i++ {
+				holeSize := 4 + i % 3
+				deck := cards.NewFullDeck()
+				deck.ShuffleWith(random)
+				hole := NewCards(deck.LeftCards()[:holeSize])
+				board := NewCards(deck.LeftCards()[holeSize:holeSize + 5])
+
+				var expected Rank
+				for _, h := range combin.Combinations(holeSize, 2) {
+					for _, b := range combin.Combinations(5, 3) {
+						rank, err := Default.Evaluate([]Card{hole[h[0]], hole[h[1]], board[b[0]], board[b[1]], board[b[2]]})
+						require.NoError(t, err)
+						if rank > expected {
+							expected = rank
+						}
+					}
+				}
+
+				rank, holeSubset, boardSubset, err := Default.StrongestOf(hole, 2, board, 3)
+				require.NoError(t, err)
+				require.Equal(t, expected, rank)
+				require.Equal(t, 2, len(holeSubset))
+				require.Equal(t, 3, len(boardSubset))
+			}
+		})
+
+		t.Run("four of a suit in hand is not a flush", func(t *testing.T) {
+			hole, err := cmd.ParseCards("AsKsQsJs")
+			require.NoError(t, err)
+			board, err := cmd.ParseCards("2s3d7h8c9d")
+			require.NoError(t, err)
+
+			rank, _, _, err := Default.StrongestOf(NewCards(hole), 2, NewCards(board), 3)
+			require.NoError(t, err)
+			require.Equal(t, cards.HighCard, rank.Type())
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		hole := NewCards(cards.NewFullDeck().LeftCards()[:4])
+		board := NewCards(cards.NewFullDeck().LeftCards()[4:9])
+
+		_, _, _, err := Default.StrongestOf(hole, 2, board, 2)
+		require.Error(t, err)
+
+		_, _, _, err = Default.StrongestOf(hole[:1], 2, board, 3)
+		require.Error(t, err)
+	})
+}
+
+func BenchmarkEvaluator_Evaluate(b *testing.B) {
+	deck := cards.NewFullDeck()
+	deck.ShuffleWith(rand.New(rand.NewSource(1)))
+	hand := NewCards(deck.LeftCards()[:7])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Default.Evaluate(hand)
+	}
+}
+
+func BenchmarkEvaluator_StrongestOf(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	hands := make([][]Card, 256)
+	for i := range hands {
+		deck := cards.NewFullDeck()
+		deck.ShuffleWith(random)
+		hands[i] = NewCards(deck.LeftCards()[:11])
+	}
+
+	for _, holeSize := range []int{4, 5, 6} {
+		b.Run(fmt.Sprintf("%d hole cards", holeSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				hand := hands[i % len(hands)]
+				_, _, _, _ = Default.StrongestOf(hand[:holeSize], 2, hand[6:11], 3)
+			}
+		})
+	}
+}
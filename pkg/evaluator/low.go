@@ -0,0 +1,182 @@
+package evaluator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/samber/lo"
+)
+
+// LowRank is a comparable strength of the best ace-to-five low hand, bigger rank wins just like for Rank.
+// Aces are low, straights and flushes don't count, zero rank means there is no low hand at all
+type LowRank uint32
+
+// Every low hand is reduced to a badness of the form:
+//
+//	cccc ffff ffff ffff ffff ffff
+//
+// c - category of repeated faces (no pair, pair, two pair, three of a kind, full house, four of a kind),
+// f - faces counting ace as the lowest, ordered by count first and from the highest second.
+// Smaller badness is the better low, rank is the distance of badness from lowRankBase
+const lowRankBase = 1 << 24
+
+const lowCategoryShift = 20
+
+const (
+	lowNoPair uint32 = iota
+	lowPair
+	lowTwoPair
+	lowThreeOfAKind
+	lowFullHouse
+	lowFourOfAKind
+)
+
+// combination type of every category of repeated faces
+var lowCategoryTypes = []cards.CombinationType{cards.HighCard, cards.Pair, cards.TwoPair, cards.ThreeOfAKind, cards.FullHouse, cards.FourOfAKind}
+
+// indexed by product of face primes
+var lowTable = newLowTable()
+
+func newLowTable() *productsTable {
+	t := &productsTable{}
+	walkFaces(func(faces []cards.Face) {
+		counts := lo.CountValues(faces)
+		if len(counts) == 1 {
+			// five of a kind is impossible with a single deck
+			return
+		}
+
+		product := uint32(1)
+		for _, face := range faces {
+			product *= facePrimes[face]
+		}
+		t.set(product, lowRankBase - lowBadness(counts))
+	})
+	return t
+}
+
+// lowFace returns value of the face in ace-to-five low, where ace is the lowest
+func lowFace(face cards.Face) uint32 {
+	return (uint32(face) + 1) % uint32(len(cards.Faces))
+}
+
+func lowCategory(counts map[cards.Face]int) uint32 {
+	values := lo.Values(counts)
+	switch {
+	case lo.Contains(values, 4):
+		return lowFourOfAKind
+	case lo.Contains(values, 3) && lo.Contains(values, 2):
+		return lowFullHouse
+	case lo.Contains(values, 3):
+		return lowThreeOfAKind
+	case lo.Count(values, 2) == 2:
+		return lowTwoPair
+	case lo.Contains(values, 2):
+		return lowPair
+	default:
+		return lowNoPair
+	}
+}
+
+func lowBadness(counts map[cards.Face]int) uint32 {
+	ordered := lo.Keys(counts)
+	sort.Slice(ordered, func(i, j int) bool {
+		if counts[ordered[i]] != counts[ordered[j]] {
+			return counts[ordered[i]] > counts[ordered[j]]
+		}
+		return lowFace(ordered[i]) > lowFace(ordered[j])
+	})
+
+	value := uint32(0)
+	for i, face := range ordered {
+		value |= lowFace(face) << (4 * uint32(cards.CombinationLength - 1 - i))
+	}
+
+	return lowCategory(counts) << lowCategoryShift | value
+}
+
+func (r LowRank) badness() uint32 {
+	return lowRankBase - uint32(r)
+}
+
+// Rank converts low rank into Rank ordered the same way, whose Type is the combination type of repeated faces,
+// so lowball hands can be compared and tallied just like high ones
+func (r LowRank) Rank() Rank {
+	if r == 0 {
+		return 0
+	}
+
+	badness := r.badness()
+	category := badness >> lowCategoryShift
+	faces := badness & (1 << lowCategoryShift - 1)
+	return Rank((lowFourOfAKind - category) << positionShift | uint32(lowCategoryTypes[category]) << typeShift | (1 << typeShift - 1 - faces))
+}
+
+// Qualifies reports whether low hand consists of five different faces, none of which is higher than given face
+func (r LowRank) Qualifies(highest cards.Face) bool {
+	if r == 0 {
+		return false
+	}
+
+	badness := r.badness()
+	return badness >> lowCategoryShift == lowNoPair && (badness >> 16) & 0xF <= lowFace(highest)
+}
+
+// IsEightOrBetter reports whether low hand qualifies for the low half of the pot in hi-lo games
+func (r LowRank) IsEightOrBetter() bool {
+	return r.Qualifies(cards.Eight)
+}
+
+func evaluateLow(product uint32) LowRank {
+	return LowRank(lowTable.get(product))
+}
+
+// Low returns rank of the best ace-to-five low 5-card hand and indexes of the cards it consists of
+func Low(cs []Card) (LowRank, []int, error) {
+	if len(cs) < cards.CombinationLength {
+		return 0, nil, fmt.Errorf("Cannot evaluate hand of {%d} cards, must be at least {%d}", len(cs), cards.CombinationLength)
+	}
+
+	var best LowRank
+	var bestSubset []int
+	for _, s := range subsetsOf(len(cs)) {
+		rank := evaluateLow(cs[s[0]].prime() * cs[s[1]].prime() * cs[s[2]].prime() * cs[s[3]].prime() * cs[s[4]].prime())
+		if bestSubset == nil || rank > best {
+			best = rank
+			bestSubset = s
+		}
+	}
+
+	return best, bestSubset, nil
+}
+
+// LowOf returns rank of the best ace-to-five low hand made of exactly holeCount cards of hole and boardCount cards of board,
+// along with indexes of hole and board cards it consists of
+func LowOf(hole []Card, holeCount int, board []Card, boardCount int) (LowRank, []int, []int, error) {
+	err := validateSplit(hole, holeCount, board, boardCount)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	var best LowRank
+	var bestHole, bestBoard []int
+
+	holeSubsets := splitSubsetsOf(len(hole), holeCount)
+	boardSubsets := splitSubsetsOf(len(board), boardCount)
+	var holeBuffer, boardBuffer [maxSplitSubsets]partialHand
+	holePartials := appendPartialHands(holeBuffer[:0], hole, holeSubsets)
+	boardPartials := appendPartialHands(boardBuffer[:0], board, boardSubsets)
+	for i, h := range holePartials {
+		for j, b := range boardPartials {
+			rank := evaluateLow(h.product * b.product)
+			if bestHole == nil || rank > best {
+				best = rank
+				bestHole = holeSubsets[i]
+				bestBoard = boardSubsets[j]
+			}
+		}
+	}
+
+	return best, bestHole, bestBoard, nil
+}
@@ -0,0 +1,144 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	cmd "github.com/anuarkaliyev23/goker/pkg/cmd/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func lowRankOf(representation string) LowRank {
+	cs, err := cmd.ParseCards(representation)
+	if err != nil {
+		panic(err)
+	}
+
+	rank, _, err := Low(NewCards(cs))
+	if err != nil {
+		panic(err)
+	}
+	return rank
+}
+
+func TestLow(t *testing.T) {
+	t.Run("ordering", func(t *testing.T) {
+		// from the best low to the worst one
+		ordered := []string{
+			"As2d3c4h5s",
+			"As2d3c4h6s",
+			"2s3d4c5h6s",
+			"As2d3c4h8s",
+			"As2d3c7h8s",
+			"9s2d3c4h5s",
+			"KsQdJcTh9s",
+			"AsAd2c3h4s",
+			"2s2d3c4h5s",
+			"AsAd2c2h3s",
+			"AsAdAc2h3s",
+			"AsAdAc2h2s",
+			"AsAdAcAh2s",
+		}
+
+		for i := 1; i < len(ordered); i++ {
+			require.Greater(t, lowRankOf(ordered[i - 1]), lowRankOf(ordered[i]), "%s should be better than %s", ordered[i - 1], ordered[i])
+		}
+	})
+
+	t.Run("straights and flushes are ignored", func(t *testing.T) {
+		require.Equal(t, lowRankOf("As2d3c4h5s"), lowRankOf("As2s3s4s5s"))
+		require.Equal(t, lowRankOf("6s7d8c9hTs"), lowRankOf("6d7d8d9dTd"))
+	})
+
+	t.Run("eight or better", func(t *testing.T) {
+		cases := map[string]bool{
+			"As2d3c4h5s": true,
+			"As2d3c4h8s": true,
+			"4s5d6c7h8s": true,
+			"As2d3c4h9s": false,
+			"AsAd2c3h4s": false,
+			"KsQdJcTh9s": false,
+		}
+
+		for representation, expected := range cases {
+			require.Equal(t, expected, lowRankOf(representation).IsEightOrBetter(), representation)
+		}
+		require.False(t, LowRank(0).IsEightOrBetter())
+	})
+
+	t.Run("best of seven cards", func(t *testing.T) {
+		cs, err := cmd.ParseCards("AsAd2c3h4sKs5d")
+		require.NoError(t, err)
+
+		rank, subset, err := Low(NewCards(cs))
+		require.NoError(t, err)
+		require.Equal(t, lowRankOf("As2d3c4h5s"), rank)
+		require.ElementsMatch(t, []cards.Face{cards.Ace, cards.Two, cards.Three, cards.Four, cards.Five}, []cards.Face{
+			cs[subset[0]].Face(), cs[subset[1]].Face(), cs[subset[2]].Face(), cs[subset[3]].Face(), cs[subset[4]].Face(),
+		})
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, _, err := Low(NewCards(cards.NewFullDeck().LeftCards()[:4]))
+		require.Error(t, err)
+	})
+}
+
+func TestLowRank_Rank(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		ordered := []string{"As2d3c4h5s", "As2d3c4h8s", "KsQdJcTh9s", "AsAd2c3h4s", "2s2d3c4h5s", "AsAd2c2h3s", "AsAdAc2h3s", "AsAdAc2h2s", "AsAdAcAh2s"}
+		for i := 1; i < len(ordered); i++ {
+			require.Greater(t, lowRankOf(ordered[i - 1]).Rank(), lowRankOf(ordered[i]).Rank(), "%s should be better than %s", ordered[i - 1], ordered[i])
+		}
+
+		types := map[string]cards.CombinationType{
+			"As2s3s4s5s": cards.HighCard,
+			"AsAd2c3h4s": cards.Pair,
+			"AsAd2c2h3s": cards.TwoPair,
+			"AsAdAc2h3s": cards.ThreeOfAKind,
+			"AsAdAc2h2s": cards.FullHouse,
+			"AsAdAcAh2s": cards.FourOfAKind,
+		}
+		for representation, expected := range types {
+			require.Equal(t, expected, lowRankOf(representation).Rank().Type(), representation)
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		require.Equal(t, Rank(0), LowRank(0).Rank())
+	})
+}
+
+func TestLowOf(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		hole, err := cmd.ParseCards("As2sKdKc")
+		require.NoError(t, err)
+		board, err := cmd.ParseCards("3h4h5h8d9c")
+		require.NoError(t, err)
+
+		rank, holeSubset, boardSubset, err := LowOf(NewCards(hole), 2, NewCards(board), 3)
+		require.NoError(t, err)
+		require.Equal(t, lowRankOf("As2d3c4h5s"), rank)
+		require.Equal(t, []int{0, 1}, holeSubset)
+		require.Equal(t, []int{0, 1, 2}, boardSubset)
+	})
+
+	t.Run("exactly two hole cards are used", func(t *testing.T) {
+		hole, err := cmd.ParseCards("AsKsQdJc")
+		require.NoError(t, err)
+		board, err := cmd.ParseCards("2h3h4h5h6d")
+		require.NoError(t, err)
+
+		rank, _, _, err := LowOf(NewCards(hole), 2, NewCards(board), 3)
+		require.NoError(t, err)
+		require.False(t, rank.IsEightOrBetter())
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		hole := NewCards(cards.NewFullDeck().LeftCards()[:4])
+		board := NewCards(cards.NewFullDeck().LeftCards()[4:9])
+
+		_, _, _, err := LowOf(hole, 3, board, 3)
+		require.Error(t, err)
+	})
+}
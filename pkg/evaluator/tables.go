@@ -0,0 +1,190 @@
+package evaluator
+
+import (
+	"sort"
+
+	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/samber/lo"
+)
+
+// Every 5-card hand is reduced to a value of the form:
+//
+//	tttt ffff ffff ffff ffff ffff
+//
+// t - cards.CombinationType, f - faces deciding between hands of the same type
+// (main card first, kickers last), so bigger value is always the stronger hand.
+const typeShift = 20
+
+type tables struct {
+	// indexed by face bits of five different faces of the same suit
+	flushes [1 << 13]uint32
+	// indexed by face bits of five different faces
+	uniques [1 << 13]uint32
+	// indexed by product of face primes, for hands with repeated faces
+	products productsTable
+}
+
+// There are 4888 hands with repeated faces, table is kept less than a third full so probes stay short
+const productsTableBits = 14
+
+// productsTable is an open addressing hash table, which is noticeably faster than a map on the hot path of evaluation
+type productsTable struct {
+	keys [1 << productsTableBits]uint32
+	values [1 << productsTableBits]uint32
+}
+
+func productSlot(product uint32) uint32 {
+	return (product * 0x9E3779B1) >> (32 - productsTableBits)
+}
+
+func (t *productsTable) set(product uint32, value uint32) {
+	slot := productSlot(product)
+	for t.keys[slot] != 0 && t.keys[slot] != product {
+		slot = (slot + 1) & (1 << productsTableBits - 1)
+	}
+	t.keys[slot] = product
+	t.values[slot] = value
+}
+
+// get returns value of the product, zero if it is not in the table
+func (t *productsTable) get(product uint32) uint32 {
+	slot := productSlot(product)
+	for t.keys[slot] != 0 {
+		if t.keys[slot] == product {
+			return t.values[slot]
+		}
+		slot = (slot + 1) & (1 << productsTableBits - 1)
+	}
+	return 0
+}
+
+// Lowest straight of the deck is ace playing low with these faces
+var defaultWheel = []cards.Face{cards.Two, cards.Three, cards.Four, cards.Five}
+var shortDeckWheel = []cards.Face{cards.Six, cards.Seven, cards.Eight, cards.Nine}
+
+var defaultTables = newTables(defaultWheel)
+var shortDeckTables = newTables(shortDeckWheel)
+// ace is always high in deuce-to-seven, so there is no wheel
+var deuceToSevenTables = newTables(nil)
+
+func newTables(wheel []cards.Face) *tables {
+	t := &tables{}
+	walkFaces(func(faces []cards.Face) {
+		t.add(faces, wheel)
+	})
+	return t
+}
+
+// walkFaces calls fn for every multiset of faces 5-card hand can have
+func walkFaces(fn func(faces []cards.Face)) {
+	faces := make([]cards.Face, cards.CombinationLength)
+	var walk func(position int, from cards.Face)
+	walk = func(position int, from cards.Face) {
+		if position == cards.CombinationLength {
+			fn(faces)
+			return
+		}
+
+		for face := from; face <= cards.Ace; face++ {
+			faces[position] = face
+			walk(position + 1, face)
+		}
+	}
+	walk(0, cards.Two)
+}
+
+func (t *tables) add(faces []cards.Face, wheel []cards.Face) {
+	counts := lo.CountValues(faces)
+	if len(counts) == 1 {
+		// five of a kind is impossible with a single deck
+		return
+	}
+
+	if len(counts) == cards.CombinationLength {
+		bits := uint32(0)
+		for _, face := range faces {
+			bits |= 1 << uint32(face)
+		}
+		t.uniques[bits] = score(counts, false, wheel)
+		t.flushes[bits] = score(counts, true, wheel)
+		return
+	}
+
+	product := uint32(1)
+	for _, face := range faces {
+		product *= facePrimes[face]
+	}
+	t.products.set(product, score(counts, false, wheel))
+}
+
+func straightHighFace(counts map[cards.Face]int, wheel []cards.Face) (cards.Face, bool) {
+	if len(counts) != cards.CombinationLength {
+		return 0, false
+	}
+
+	faces := lo.Keys(counts)
+	sort.Slice(faces, func(i, j int) bool {
+		return faces[i] < faces[j]
+	})
+
+	if faces[4] - faces[0] == 4 {
+		return faces[4], true
+	}
+
+	if faces[4] == cards.Ace && len(wheel) > 0 {
+		if lo.Every(faces[:4], wheel) {
+			return wheel[3], true
+		}
+	}
+
+	return 0, false
+}
+
+func combinationType(counts map[cards.Face]int, flush bool, straight bool) cards.CombinationType {
+	values := lo.Values(counts)
+	switch {
+	case flush && straight:
+		return cards.StraightFlush
+	case lo.Contains(values, 4):
+		return cards.FourOfAKind
+	case lo.Contains(values, 3) && lo.Contains(values, 2):
+		return cards.FullHouse
+	case flush:
+		return cards.Flush
+	case straight:
+		return cards.Straight
+	case lo.Contains(values, 3):
+		return cards.ThreeOfAKind
+	case lo.Count(values, 2) == 2:
+		return cards.TwoPair
+	case lo.Contains(values, 2):
+		return cards.Pair
+	default:
+		return cards.HighCard
+	}
+}
+
+func score(counts map[cards.Face]int, flush bool, wheel []cards.Face) uint32 {
+	highFace, straight := straightHighFace(counts, wheel)
+	ctype := combinationType(counts, flush, straight)
+
+	if straight {
+		return uint32(ctype) << typeShift | uint32(highFace)
+	}
+
+	// faces ordered by their count first and by face second, e.g. KK 77 A for two pairs
+	ordered := lo.Keys(counts)
+	sort.Slice(ordered, func(i, j int) bool {
+		if counts[ordered[i]] != counts[ordered[j]] {
+			return counts[ordered[i]] > counts[ordered[j]]
+		}
+		return ordered[i] > ordered[j]
+	})
+
+	value := uint32(0)
+	for i, face := range ordered {
+		value |= uint32(face) << (4 * uint32(cards.CombinationLength - 1 - i))
+	}
+
+	return uint32(ctype) << typeShift | value
+}
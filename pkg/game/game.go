@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/anuarkaliyev23/goker/pkg/cards"
+	"github.com/samber/lo"
 )
 
 type Game int
@@ -12,9 +13,44 @@ const (
 	Texas Game = iota
 	ShortDeck
 	Omaha
+	// Pot Limit Omaha with 5 hole cards
+	Omaha5
+	// Pot Limit Omaha with 6 hole cards
+	Omaha6
+	// Omaha Hi-Lo 8-or-better
+	OmahaHiLo
+	// Seven Card Stud, high hand wins
+	Stud
+	// Seven Card Stud Hi-Lo 8-or-better
+	StudHiLo
+	// Seven Card Stud, ace-to-five low hand wins
+	Razz
+	// Five Card Draw, high hand wins after a single draw
+	FiveCardDraw
+	// Deuce-to-seven lowball with three draws
+	TripleDraw
+	// Hold'em with three hole cards, one of which is discarded before the flop
+	Pineapple
+	// Hold'em with three hole cards, one of which is discarded after the flop
+	CrazyPineapple
 	Custom
 )
 
+// IsOmaha reports whether hand is made of exactly two hole cards and three community cards
+func (g Game) IsOmaha() bool {
+	return g == Omaha || g == Omaha5 || g == Omaha6 || g == OmahaHiLo
+}
+
+// IsStud reports whether hand is made of any five of player's own cards, without any board
+func (g Game) IsStud() bool {
+	return g == Stud || g == StudHiLo || g == Razz
+}
+
+// IsDraw reports whether players replace their own cards instead of sharing a board
+func (g Game) IsDraw() bool {
+	return g == FiveCardDraw || g == TripleDraw
+}
+
 type Config struct {
 	Game Game
 
@@ -24,14 +60,91 @@ type Config struct {
 
 	HoleCardsAllowedToUseCount int
 	CommunityCardsAllowedToUseCount int
+	// Hand is made of exactly HoleCardsAllowedToUseCount hole and CommunityCardsAllowedToUseCount community cards, as in Omaha.
+	// Otherwise they are upper limits, as in Texas Hold'em
+	MustUseExactly bool
 
 	MaxPlayers int
+
+	// Deck has no faces from deuce to five, so ace makes the lowest straight with six to nine
+	ShortDeck bool
+	// High hand combination types from the weakest to the strongest, the usual order of the deck if not set
+	CombinationStrengths []cards.CombinationType
+
+	// Number of player's cards dealt face up, which every player sees. Zero for games with hole cards only
+	UpCardsCount int
+
+	// Number of hole cards every player discards, zero for games without discards
+	DiscardsCount int
+	// Number of board cards dealt before players discard, zero if they discard before the flop
+	DiscardBoardSize int
+
+	// Number of draws players can replace their cards on, zero for games without draws
+	DrawsCount int
+
+	// How hands are compared, the best high hand wins unless it is a lowball game
+	Ranking cards.Ranking
+
+	// Pot is split between the best high hand and the best ace-to-five low hand, which qualifies as eight-or-better.
+	// High hand takes the whole pot if there is no qualifying low
+	HiLo bool
+}
+
+// DownCardsCount returns number of player's cards dealt face down, which only the player sees
+func (r Config) DownCardsCount() int {
+	return r.HoleCardsCount - r.UpCardsCount
 }
 
 func (r Config) CardsUsedForPlayer() int {
 	return r.HoleCardsCount + r.CommunityCardsCount
 }
 
+// NewDeck constructs deck the game is played with, full deck is used if DeckGenerator is not set
+func (r Config) NewDeck() cards.Deck {
+	if r.DeckGenerator == nil {
+		return cards.NewFullDeck()
+	}
+	return r.DeckGenerator()
+}
+
+// Validate checks that a hand can be made of the cards game allows to use and that combination strengths are complete
+func (r Config) Validate() error {
+	holeCards := min(r.HoleCardsCount, r.HoleCardsAllowedToUseCount)
+	communityCards := min(r.CommunityCardsCount, r.CommunityCardsAllowedToUseCount)
+
+	if r.MustUseExactly {
+		if r.HoleCardsAllowedToUseCount + r.CommunityCardsAllowedToUseCount != cards.CombinationLength || holeCards != r.HoleCardsAllowedToUseCount || communityCards != r.CommunityCardsAllowedToUseCount {
+			return fmt.Errorf("Cannot make a hand of exactly {%d} of {%d} hole and {%d} of {%d} community cards, must be {%d} in total", r.HoleCardsAllowedToUseCount, r.HoleCardsCount, r.CommunityCardsAllowedToUseCount, r.CommunityCardsCount, cards.CombinationLength)
+		}
+	} else if holeCards + communityCards < cards.CombinationLength {
+		return fmt.Errorf("Cannot make a hand of up to {%d} hole and {%d} community cards, must be at least {%d} in total", holeCards, communityCards, cards.CombinationLength)
+	}
+
+	if len(r.CombinationStrengths) > 0 {
+		complete := len(r.CombinationStrengths) == len(cards.DefaultCombinationStrength) &&
+			len(lo.Uniq(r.CombinationStrengths)) == len(r.CombinationStrengths) &&
+			lo.Every(cards.DefaultCombinationStrength, r.CombinationStrengths)
+		if !complete {
+			return fmt.Errorf("Combination strengths {%v} should order every combination type exactly once", r.CombinationStrengths)
+		}
+	}
+
+	return nil
+}
+
+// isShortDeck reports whether deck of the generator has no faces from deuce to five
+func isShortDeck(deckGenerator func() cards.Deck) bool {
+	if deckGenerator == nil {
+		return false
+	}
+
+	return !lo.SomeBy(deckGenerator().LeftCards(), func(card cards.Card) bool {
+		return card.Face() < cards.Six
+	})
+}
+
+// NewCustomConfig constructs a game, where hand is made of up to given numbers of hole and community cards.
+// Set MustUseExactly for Omaha-like games and CombinationStrengths for a different order of combinations
 func NewCustomConfig(
 		deckGenerator func() cards.Deck,
 		holeCardsCount, 
@@ -48,6 +161,7 @@ func NewCustomConfig(
 		HoleCardsAllowedToUseCount: holeCardsAllowedToUseCount,
 		CommunityCardsAllowedToUseCount: communityCardsAllowedToUseCount,
 		MaxPlayers: maxPlayers,
+		ShortDeck: isShortDeck(deckGenerator),
 	}
 }
 
@@ -58,6 +172,26 @@ func NewConfig(game Game) (Config, error) {
 		return NewOmahaConfig(), nil
 	} else if game == ShortDeck {
 		return NewShortDeckConfig(), nil
+	} else if game == Omaha5 {
+		return NewOmaha5Config(), nil
+	} else if game == Omaha6 {
+		return NewOmaha6Config(), nil
+	} else if game == OmahaHiLo {
+		return NewOmahaHiLoConfig(), nil
+	} else if game == Stud {
+		return NewStudConfig(), nil
+	} else if game == StudHiLo {
+		return NewStudHiLoConfig(), nil
+	} else if game == Razz {
+		return NewRazzConfig(), nil
+	} else if game == FiveCardDraw {
+		return NewFiveCardDrawConfig(), nil
+	} else if game == TripleDraw {
+		return NewTripleDrawConfig(), nil
+	} else if game == Pineapple {
+		return NewPineappleConfig(), nil
+	} else if game == CrazyPineapple {
+		return NewCrazyPineappleConfig(), nil
 	} else {
 		return Config{}, fmt.Errorf("could not construct config for game=[%v]", game)
 	}
@@ -90,6 +224,7 @@ func NewShortDeckConfig() Config {
 		CommunityCardsAllowedToUseCount: 5,
 
 		MaxPlayers: 10,
+		ShortDeck: true,
 	}
 }
 
@@ -103,7 +238,134 @@ func NewOmahaConfig() Config {
 
 		HoleCardsAllowedToUseCount: 2,
 		CommunityCardsAllowedToUseCount: 3,
+		MustUseExactly: true,
+
+		MaxPlayers: 10,
+	}
+}
+
+func NewOmaha5Config() Config {
+	return Config {
+		Game: Omaha5,
+
+		DeckGenerator: cards.NewFullDeck,
+		HoleCardsCount: 5,
+		CommunityCardsCount: 5,
+
+		HoleCardsAllowedToUseCount: 2,
+		CommunityCardsAllowedToUseCount: 3,
+		MustUseExactly: true,
+
+		MaxPlayers: 9,
+	}
+}
+
+func NewOmaha6Config() Config {
+	return Config {
+		Game: Omaha6,
+
+		DeckGenerator: cards.NewFullDeck,
+		HoleCardsCount: 6,
+		CommunityCardsCount: 5,
+
+		HoleCardsAllowedToUseCount: 2,
+		CommunityCardsAllowedToUseCount: 3,
+		MustUseExactly: true,
+
+		MaxPlayers: 7,
+	}
+}
+
+// NewOmahaHiLoConfig constructs Omaha 8-or-better, where both high and low hands use exactly two hole and three board cards
+func NewOmahaHiLoConfig() Config {
+	config := NewOmahaConfig()
+	config.Game = OmahaHiLo
+	config.HiLo = true
+	return config
+}
+
+// NewStudConfig constructs Seven Card Stud, where every player gets three down and four up cards and there is no board.
+// Eight players would run out of cards by the last street, so stud is limited to seven
+func NewStudConfig() Config {
+	return Config {
+		Game: Stud,
+
+		DeckGenerator: cards.NewFullDeck,
+		HoleCardsCount: 7,
+		CommunityCardsCount: 0,
+
+		HoleCardsAllowedToUseCount: 5,
+		CommunityCardsAllowedToUseCount: 0,
+
+		MaxPlayers: 7,
+		UpCardsCount: 4,
+	}
+}
+
+// NewStudHiLoConfig constructs Seven Card Stud 8-or-better
+func NewStudHiLoConfig() Config {
+	config := NewStudConfig()
+	config.Game = StudHiLo
+	config.HiLo = true
+	return config
+}
+
+// NewRazzConfig constructs Razz, Seven Card Stud where the best ace-to-five low takes the whole pot without any qualifier
+func NewRazzConfig() Config {
+	config := NewStudConfig()
+	config.Game = Razz
+	config.Ranking = cards.AceToFiveRanking
+	return config
+}
+
+// NewFiveCardDrawConfig constructs Five Card Draw, where every player gets five cards and can replace any of them once
+func NewFiveCardDrawConfig() Config {
+	return Config {
+		Game: FiveCardDraw,
+
+		DeckGenerator: cards.NewFullDeck,
+		HoleCardsCount: 5,
+		CommunityCardsCount: 0,
+
+		HoleCardsAllowedToUseCount: 5,
+		CommunityCardsAllowedToUseCount: 0,
+
+		MaxPlayers: 6,
+		DrawsCount: 1,
+	}
+}
+
+// NewTripleDrawConfig constructs 2-7 Triple Draw, deuce-to-seven lowball with three draws
+func NewTripleDrawConfig() Config {
+	config := NewFiveCardDrawConfig()
+	config.Game = TripleDraw
+	config.DrawsCount = 3
+	config.Ranking = cards.DeuceToSevenRanking
+	return config
+}
+
+// NewPineappleConfig constructs Pineapple, where every player discards one of three hole cards before the flop
+// and plays the rest as in Texas Hold'em
+func NewPineappleConfig() Config {
+	return Config{
+		Game: Pineapple,
+
+		DeckGenerator: cards.NewFullDeck,
+		HoleCardsCount: 3,
+		CommunityCardsCount: 5,
+
+		HoleCardsAllowedToUseCount: 2,
+		CommunityCardsAllowedToUseCount: 5,
 
 		MaxPlayers: 10,
+		DiscardsCount: 1,
 	}
 }
+
+// NewCrazyPineappleConfig constructs Crazy Pineapple, where the discard happens after the flop
+func NewCrazyPineappleConfig() Config {
+	config := NewPineappleConfig()
+	config.Game = CrazyPineapple
+	config.DiscardBoardSize = 3
+	return config
+}